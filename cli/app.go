@@ -3,16 +3,20 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws/external"
 	"github.com/func/func/cloudformation"
+	"github.com/func/func/history"
+	"github.com/func/func/project"
 	"github.com/func/func/provider/aws/apigatewayv2"
 	"github.com/func/func/provider/aws/iam"
 	"github.com/func/func/provider/aws/lambda"
@@ -30,7 +34,8 @@ type App struct {
 	Log    *logger
 	Stdout io.Writer
 
-	loader *resource.Loader
+	loader   *resource.Loader
+	projects *project.Loader
 }
 
 // NewApp creates a new cli app.
@@ -41,18 +46,57 @@ func NewApp(verbose bool) *App {
 	}
 }
 
-func (a *App) loadResources(dir string) (resource.List, hcl.Diagnostics) {
-	if a.loader == nil {
-		reg := &resource.Registry{}
-		iam.Register(reg)
-		lambda.Register(reg)
-		apigatewayv2.Register(reg)
+// loadProject finds the project for the given directory and selects the
+// environment, if set. The returned directory is the project root, or dir if
+// no project was found.
+func (a *App) loadProject(dir, env string, step *logStep) (*project.Project, string, bool) {
+	if a.projects == nil {
+		a.projects = &project.Loader{}
+	}
+	proj, diags := a.projects.Find(dir)
+	step.PrintDiags(diags, a.projects.Files())
+	if diags.HasErrors() {
+		return nil, "", false
+	}
+	if proj == nil {
+		if env != "" {
+			step.Errorf("Environment %q set but no project found", env)
+			return nil, "", false
+		}
+		return nil, dir, true
+	}
+	step.Verbosef("Project: %s", proj.Dir)
+	if env != "" {
+		p, err := proj.Select(env)
+		if err != nil {
+			step.Errorf("Could not select environment: %v", err)
+			return nil, "", false
+		}
+		proj = p
+		step.Verbosef("Environment: %s", env)
+	}
+	return proj, proj.Dir, true
+}
+
+// registry returns a registry with all supported resources.
+func registry() *resource.Registry {
+	reg := &resource.Registry{}
+	iam.Register(reg)
+	lambda.Register(reg)
+	apigatewayv2.Register(reg)
+	return reg
+}
 
+func (a *App) loadResources(dir string, proj *project.Project) (resource.List, hcl.Diagnostics) {
+	if a.loader == nil {
 		a.loader = &resource.Loader{
-			Registry: reg,
+			Registry: registry(),
 		}
 	}
 
+	// Variables are available without a project, set to their defaults.
+	a.loader.Context = proj.EvalContext()
+
 	return a.loader.LoadDir(dir)
 }
 
@@ -66,7 +110,7 @@ type sourcecode struct {
 func sources(resources resource.List) ([]sourcecode, error) {
 	sources := resources.WithSource()
 	out := make([]sourcecode, len(sources))
-	g, _ := errgroup.WithContext(context.Background())
+	var g errgroup.Group
 	for i, res := range sources {
 		i, res := i, res
 		g.Go(func() error {
@@ -89,12 +133,40 @@ func sources(resources resource.List) ([]sourcecode, error) {
 	return out, nil
 }
 
-func ensureSource(ctx context.Context, src sourcecode, s3 *source.S3, step *logStep) error {
+// cacheDir returns the directory to cache build artifacts and dependencies
+// in. If the user cache directory cannot be determined, a directory in the
+// temp dir is used.
+func cacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "func-cache")
+	}
+	return filepath.Join(dir, "func")
+}
+
+// buildEnvLog returns the build environment variables for logging, with
+// secret values redacted.
+func buildEnvLog(src *source.Code) []string {
+	out := make([]string, 0, len(src.Env)+len(src.SecretEnv))
+	for k, v := range src.Env {
+		out = append(out, k+"="+v)
+	}
+	for _, name := range src.SecretEnv {
+		out = append(out, name+"=***")
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ensureSource ensures the source code archive exists in the artifact store.
+// The archive is built, or read from the local cache, and uploaded if it does
+// not exist.
+func ensureSource(ctx context.Context, src sourcecode, cache *source.Cache, store source.ArtifactStore, step *logStep) error {
 	step.Icon = true
 	step.Verbosef("Dir:      %s", src.Source.Files.Root)
 	step.Verbosef("Checksum: %s", src.Checksum[0:12])
 
-	exists, err := s3.Has(ctx, src.Key)
+	exists, err := store.Has(ctx, src.Key)
 	if err != nil {
 		return fmt.Errorf("check existing source: %w", err)
 	}
@@ -102,6 +174,58 @@ func ensureSource(ctx context.Context, src sourcecode, s3 *source.S3, step *logS
 		return nil
 	}
 
+	zipfile, err := buildSource(ctx, src, cache, step)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(zipfile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	uploadStep := step.Step("Upload")
+	progress := newProgressBar(16)
+	progress.SetProgress(0.75)
+	uploadStep.Push(progress)
+	r := &uploadReader{
+		File:     f,
+		Progress: progress,
+		Size:     stat.Size(),
+	}
+	err = store.Upload(ctx, src.Key, r)
+	if err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+	uploadStep.Remove(progress)
+	uploadStep.Done()
+	return nil
+}
+
+// buildSource returns the path to the source code archive in the local cache.
+// The source code is built and compressed if it is not already cached.
+//
+// Archives are cached by the build checksum, so the source code is built
+// again if a tool or the image used in the build changes.
+func buildSource(ctx context.Context, src sourcecode, cache *source.Cache, step *logStep) (string, error) {
+	buildSum, err := src.Source.BuildChecksum(ctx)
+	if err != nil {
+		return "", fmt.Errorf("compute build checksum: %w", err)
+	}
+	step.Verbosef("Build:    %s", buildSum[0:12])
+	cacheKey := buildSum + ".zip"
+	if zipfile, ok := cache.Get(cacheKey); ok {
+		step.Verbosef("Cached:   %s", zipfile)
+		return zipfile, nil
+	}
+
 	files := src.Source.Files
 
 	if len(src.Source.Build) > 0 {
@@ -109,13 +233,24 @@ func ensureSource(ctx context.Context, src sourcecode, s3 *source.S3, step *logS
 
 		buildDir, err := ioutil.TempDir("", "func-build")
 		if err != nil {
-			return err
+			return "", err
 		}
 		defer func() {
 			_ = os.RemoveAll(buildDir)
 		}()
 		if err := files.Copy(buildDir); err != nil {
-			return err
+			return "", err
+		}
+
+		env, err := src.Source.BuildEnv()
+		if err != nil {
+			return "", err
+		}
+		for _, kv := range buildEnvLog(src.Source) {
+			buildScriptStep.Verbosef("Env: %s", kv)
+		}
+		if src.Source.Image != "" {
+			buildScriptStep.Verbosef("Image: %s", src.Source.Image)
 		}
 
 		for i, s := range src.Source.Build {
@@ -123,13 +258,16 @@ func ensureSource(ctx context.Context, src sourcecode, s3 *source.S3, step *logS
 			io := &window{MaxLines: 3}
 			buildStep.Push(io)
 			buildContext := &source.BuildContext{
-				Dir:    buildDir,
-				Stdout: io,
-				Stderr: io,
+				Dir:     buildDir,
+				Env:     env,
+				Stdout:  io,
+				Stderr:  io,
+				Secrets: src.Source.SecretEnv,
+				Image:   src.Source.Image,
 			}
 			if err := s.Exec(ctx, buildContext); err != nil {
 				buildStep.Errorf("Step failed: %v", err)
-				return fmt.Errorf("exec step %d: %s: %w", i, s, err)
+				return "", fmt.Errorf("exec step %d: %s: %w", i, s, err)
 			}
 			// buildStep.Remove(io)
 			buildStep.Done()
@@ -138,57 +276,67 @@ func ensureSource(ctx context.Context, src sourcecode, s3 *source.S3, step *logS
 		collectStep := step.Step("Collect build artifacts")
 		output, err := source.Collect(buildDir)
 		if err != nil {
-			return err
+			return "", err
 		}
 		files = output
 		collectStep.Verbosef("Got %d build artifacts", len(files.Files))
 		collectStep.Done()
 
 		buildScriptStep.Done()
-	}
+	} else if builder := src.Source.Builder; builder != nil {
+		buildStep := step.Step("Build (" + builder.Name() + ")")
 
-	compressStep := step.Step("Compress")
-	zipfile := strings.Replace(src.Key, ".", "-*.", 1)
-	f, err := ioutil.TempFile("", zipfile)
-	if err != nil {
-		return fmt.Errorf("create source file: %w", err)
-	}
-	defer func() {
-		_ = os.Remove(f.Name())
-	}()
+		outDir, err := ioutil.TempDir("", "func-build")
+		if err != nil {
+			return "", err
+		}
+		defer func() {
+			_ = os.RemoveAll(outDir)
+		}()
 
-	if err := files.Zip(f); err != nil {
-		return fmt.Errorf("zip: %w", err)
-	}
-	if err := f.Sync(); err != nil {
-		return err
-	}
-	if _, err := f.Seek(0, 0); err != nil {
-		return err
-	}
-	compressStep.Done()
+		env, err := src.Source.BuildEnv()
+		if err != nil {
+			return "", err
+		}
+		for _, kv := range buildEnvLog(src.Source) {
+			buildStep.Verbosef("Env: %s", kv)
+		}
+		if src.Source.Image != "" {
+			buildStep.Verbosef("Image: %s", src.Source.Image)
+		}
 
-	stat, err := f.Stat()
-	if err != nil {
-		return err
-	}
+		io := &window{MaxLines: 3}
+		buildStep.Push(io)
+		buildContext := &source.BuildContext{
+			Dir:      outDir,
+			Env:      env,
+			Stdout:   io,
+			Stderr:   io,
+			Secrets:  src.Source.SecretEnv,
+			CacheDir: cache.Dir,
+			Image:    src.Source.Image,
+		}
+		if err := builder.Build(ctx, files, src.Source.Target, buildContext); err != nil {
+			buildStep.Errorf("Build failed: %v", err)
+			return "", fmt.Errorf("build: %w", err)
+		}
 
-	uploadStep := step.Step("Upload")
-	progress := newProgressBar(16)
-	progress.SetProgress(0.75)
-	uploadStep.Push(progress)
-	r := &uploadReader{
-		File:     f,
-		Progress: progress,
-		Size:     stat.Size(),
+		output, err := source.Collect(outDir)
+		if err != nil {
+			return "", err
+		}
+		files = output
+		buildStep.Verbosef("Got %d build artifacts", len(files.Files))
+		buildStep.Done()
 	}
-	err = s3.Upload(ctx, src.Key, r)
+
+	compressStep := step.Step("Compress")
+	zipfile, err := cache.Put(cacheKey, files.Zip)
 	if err != nil {
-		return fmt.Errorf("upload: %w", err)
+		return "", fmt.Errorf("zip: %w", err)
 	}
-	uploadStep.Remove(progress)
-	uploadStep.Done()
-	return nil
+	compressStep.Done()
+	return zipfile, nil
 }
 
 type uploadReader struct {
@@ -221,30 +369,63 @@ func (r *uploadReader) Seek(offset int64, whence int) (int64, error) {
 	return r.File.Seek(offset, whence)
 }
 
-func sourceLocations(sources []sourcecode, bucket string) map[string]cloudformation.S3Location {
+func sourceLocations(sources []sourcecode, store source.ArtifactStore) map[string]cloudformation.S3Location {
 	out := make(map[string]cloudformation.S3Location, len(sources))
 	for _, src := range sources {
+		loc := store.Location(src.Key)
 		out[src.Resource.Name] = cloudformation.S3Location{
-			Bucket: bucket,
-			Key:    src.Key,
+			Bucket: loc.Bucket,
+			Key:    loc.Key,
 		}
 	}
 	return out
 }
 
+// referenceStore returns an artifact store for source code that is only
+// referenced, not uploaded. Archives built without uploading them are stored
+// in the local cache.
+func referenceStore(bucket string) source.ArtifactStore {
+	return &source.LocalStore{Dir: cacheDir(), Bucket: bucket}
+}
+
 // GenerateCloudFormationOpts contains options for generating a CloudFormation
 // template.
 type GenerateCloudFormationOpts struct {
 	Format        string
+	Env           string
 	SourceBucket  string
 	ProcessSource bool
+	AWS           AWSOpts
+
+	// Offline only builds source code to the local cache, it is not
+	// uploaded. Requires ProcessSource.
+	Offline bool
+
+	// SourceDir stores source code in a local directory instead of the
+	// source bucket. The directory must be copied to the bucket before the
+	// template is deployed.
+	SourceDir string
+}
+
+func (opts *GenerateCloudFormationOpts) setDefaults(p *project.Project) {
+	if opts.SourceBucket == "" {
+		opts.SourceBucket = p.SourceBucket
+	}
+	opts.AWS.setDefaults(p)
 }
 
 // GenerateCloudFormation generates a CloudFormation template from the
 // resources in the given directory.
 func (a *App) GenerateCloudFormation(ctx context.Context, dir string, opts GenerateCloudFormationOpts) int {
 	step := a.Log.Step("Load resource configurations")
-	resources, diags := a.loadResources(dir)
+	proj, dir, ok := a.loadProject(dir, opts.Env, step)
+	if !ok {
+		return 1
+	}
+	if proj != nil {
+		opts.setDefaults(proj)
+	}
+	resources, diags := a.loadResources(dir, proj)
 	step.PrintDiags(diags, a.loader.Files())
 	if diags.HasErrors() {
 		return 1
@@ -256,24 +437,53 @@ func (a *App) GenerateCloudFormation(ctx context.Context, dir string, opts Gener
 		a.Log.Errorf("Could not collect source files: %v", err)
 		return 1
 	}
+	if opts.Offline && !opts.ProcessSource {
+		a.Log.Errorf("Offline is only supported when processing source code")
+		return 2
+	}
+
+	if len(srcs) > 0 && opts.SourceBucket == "" && !opts.Offline {
+		cfg, err := opts.AWS.config()
+		if err != nil {
+			a.Log.Errorf("Could not load aws config: %v", err)
+			return 1
+		}
+		bucket, err := sourceBucket(ctx, cloudformation.NewClient(cfg), opts.SourceBucket, opts.AWS)
+		if err != nil {
+			a.Log.Errorf("Could not get bootstrap source bucket: %v", err)
+			return 1
+		}
+		opts.SourceBucket = bucket
+	}
 	if len(srcs) > 0 && opts.SourceBucket == "" {
-		a.Log.Errorf("Source bucket not set")
+		a.Log.Errorf("Source bucket not set, run func bootstrap or set --source-bucket")
 		return 2
 	}
 
-	if opts.ProcessSource {
-		cfg, err := external.LoadDefaultAWSConfig()
+	var store source.ArtifactStore
+	switch {
+	case opts.SourceDir != "":
+		store = &source.LocalStore{Dir: opts.SourceDir, Bucket: opts.SourceBucket}
+	case opts.Offline, !opts.ProcessSource:
+		store = referenceStore(opts.SourceBucket)
+	default:
+		cfg, err := opts.AWS.config()
 		if err != nil {
 			a.Log.Errorf("Could not load aws config: %v", err)
+			return 1
 		}
-		s3 := source.NewS3(cfg, opts.SourceBucket)
+		store = opts.AWS.s3(cfg, opts.SourceBucket)
+	}
+
+	if opts.ProcessSource {
+		cache := &source.Cache{Dir: cacheDir()}
 
 		srcStep := a.Log.Step("Process source code")
 		g, gctx := errgroup.WithContext(ctx)
 		for _, src := range srcs {
 			src := src
 			g.Go(func() error {
-				if err := ensureSource(gctx, src, s3, srcStep); err != nil {
+				if err := ensureSource(gctx, src, cache, store, srcStep); err != nil {
 					return fmt.Errorf("%s: %w", src.Resource.Name, err)
 				}
 				return nil
@@ -289,7 +499,7 @@ func (a *App) GenerateCloudFormation(ctx context.Context, dir string, opts Gener
 	}
 
 	step = a.Log.Step("Generate CloudFormation template")
-	locs := sourceLocations(srcs, opts.SourceBucket)
+	locs := sourceLocations(srcs, store)
 	tmpl, diags := cloudformation.Generate(resources, locs)
 	step.PrintDiags(diags, a.loader.Files())
 	if diags.HasErrors() {
@@ -332,16 +542,52 @@ func (a *App) GenerateCloudFormation(ctx context.Context, dir string, opts Gener
 // CloudFormation.
 type DeploymentOpts struct {
 	StackName    string
+	Env          string
 	SourceBucket string
+	AWS          AWSOpts
+
+	// Target is the name of the deployment target to deploy to. If not set,
+	// the project is deployed to all targets.
+	Target string
+
+	// Stack options
+	Tags                  map[string]string
+	RoleARN               string
+	NotificationARNs      []string
+	RollbackAlarms        []string
+	RollbackMonitoring    time.Duration
+	TerminationProtection bool
 }
 
-// DeployCloudFormation deploys the project using CloudFormation.
-func (a *App) DeployCloudFormation(ctx context.Context, dir string, opts DeploymentOpts) int {
+func (opts *DeploymentOpts) setDefaults(p *project.Project) {
 	if opts.StackName == "" {
-		a.Log.Errorf("Stack name not set")
-		return 2
+		opts.StackName = p.Stack
+	}
+	if opts.SourceBucket == "" {
+		opts.SourceBucket = p.SourceBucket
+	}
+	opts.AWS.setDefaults(p)
+}
+
+func (opts DeploymentOpts) changeSetOpts() []cloudformation.ChangeSetOpt {
+	var out []cloudformation.ChangeSetOpt
+	if len(opts.Tags) > 0 {
+		out = append(out, cloudformation.WithTags(opts.Tags))
+	}
+	if opts.RoleARN != "" {
+		out = append(out, cloudformation.WithRoleARN(opts.RoleARN))
+	}
+	if len(opts.NotificationARNs) > 0 {
+		out = append(out, cloudformation.WithNotificationARNs(opts.NotificationARNs...))
 	}
+	if len(opts.RollbackAlarms) > 0 {
+		out = append(out, cloudformation.WithRollbackTriggers(opts.RollbackMonitoring, opts.RollbackAlarms...))
+	}
+	return out
+}
 
+// DeployCloudFormation deploys the project using CloudFormation.
+func (a *App) DeployCloudFormation(ctx context.Context, dir string, opts DeploymentOpts) int {
 	defer func() {
 		// Better way to ensure render completes
 		time.Sleep(200 * time.Millisecond)
@@ -352,7 +598,21 @@ func (a *App) DeployCloudFormation(ctx context.Context, dir string, opts Deploym
 	)
 
 	step := a.Log.Step("Load resource configurations")
-	resources, diags := a.loadResources(dir)
+	proj, dir, ok := a.loadProject(dir, opts.Env, step)
+	if !ok {
+		return 1
+	}
+	// Targets are resolved from the options before project defaults are
+	// set, so that only flags override values set in targets.
+	targets, err := opts.targets(proj)
+	if err != nil {
+		step.Errorf("%v", err)
+		return 2
+	}
+	if proj != nil {
+		opts.setDefaults(proj)
+	}
+	resources, diags := a.loadResources(dir, proj)
 	step.PrintDiags(diags, a.loader.Files())
 	if diags.HasErrors() {
 		return 1
@@ -364,21 +624,232 @@ func (a *App) DeployCloudFormation(ctx context.Context, dir string, opts Deploym
 		a.Log.Errorf("Could not collect source files: %v", err)
 		return 1
 	}
-	if len(srcs) > 0 && opts.SourceBucket == "" {
-		a.Log.Errorf("Source bucket not set")
+
+	in := deployInput{
+		Resources: resources,
+		Sources:   srcs,
+		User:      currentUser(),
+		Commit:    gitCommit(ctx, dir),
+		generate:  &sync.Mutex{},
+	}
+
+	if len(targets) == 1 {
+		return a.deploy(ctx, a.Log, targets[0], opts, in)
+	}
+
+	// Deploy to all targets concurrently
+	codes := make([]int, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		i, target := i, target
+		step := a.Log.Step(fmt.Sprintf("%s (%s)", target.Name, target.AWS.Region))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes[i] = a.deploy(ctx, step, target, opts, in)
+			if codes[i] != 0 {
+				step.Fail()
+				return
+			}
+			step.Done()
+		}()
+	}
+	wg.Wait()
+
+	code := 0
+	var failed []string
+	for i, c := range codes {
+		if c != 0 {
+			failed = append(failed, targets[i].Name)
+		}
+		if c > code {
+			code = c
+		}
+	}
+	if len(failed) > 0 {
+		a.Log.Errorf("Deployment failed for %d of %d targets: %s", len(failed), len(targets), strings.Join(failed, ", "))
+	}
+	return code
+}
+
+// A deployTarget is a stack to deploy the project to.
+type deployTarget struct {
+	Name         string
+	Account      string
+	StackName    string
+	SourceBucket string
+	AWS          AWSOpts
+}
+
+// targets returns the targets to deploy to. If the project does not define
+// any targets, a single target is returned, based on the options.
+//
+// Options set with flags take precedence over values set in a target, which
+// take precedence over values set in the project. Flags that override target
+// values can only be used when a single target is selected, otherwise every
+// target would be deployed to the same stack. The options must not have
+// project defaults set.
+//
+// The source bucket of the project is only used for targets in the region of
+// the project, as the source code must be in the same region as the stack.
+func (opts DeploymentOpts) targets(p *project.Project) ([]deployTarget, error) {
+	if p == nil || len(p.Targets) == 0 {
+		if opts.Target != "" {
+			return nil, fmt.Errorf("target %q not found, the project does not define any targets", opts.Target)
+		}
+		if p != nil {
+			opts.setDefaults(p)
+		}
+		return []deployTarget{{
+			StackName:    opts.StackName,
+			SourceBucket: opts.SourceBucket,
+			AWS:          opts.AWS,
+		}}, nil
+	}
+
+	selected := p.Targets
+	if opts.Target != "" {
+		selected = nil
+		for _, t := range p.Targets {
+			if t.Name == opts.Target {
+				selected = append(selected, t)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("target %q not found", opts.Target)
+		}
+	}
+	if len(selected) > 1 {
+		flags := []struct {
+			name  string
+			value string
+		}{
+			{"--stack", opts.StackName},
+			{"--source-bucket", opts.SourceBucket},
+			{"--region", opts.AWS.Region},
+			{"--profile", opts.AWS.Profile},
+			{"--assume-role-arn", opts.AWS.AssumeRoleARN},
+		}
+		for _, f := range flags {
+			if f.value != "" {
+				return nil, fmt.Errorf("%s cannot be used with multiple targets, select a target with --target", f.name)
+			}
+		}
+	}
+
+	out := make([]deployTarget, len(selected))
+	for i, t := range selected {
+		target := deployTarget{
+			Name:      t.Name,
+			Account:   t.Account,
+			StackName: firstSet(opts.StackName, t.Stack, p.Stack),
+			AWS:       opts.AWS,
+		}
+		target.AWS.Region = firstSet(opts.AWS.Region, t.Region, p.Region)
+		target.AWS.Profile = firstSet(opts.AWS.Profile, t.Profile, p.Profile)
+		target.AWS.AssumeRoleARN = firstSet(opts.AWS.AssumeRoleARN, t.AssumeRoleARN)
+		target.SourceBucket = firstSet(opts.SourceBucket, t.SourceBucket)
+		if target.SourceBucket == "" && target.AWS.Region == p.Region {
+			target.SourceBucket = p.SourceBucket
+		}
+		out[i] = target
+	}
+	return out, nil
+}
+
+// firstSet returns the first non-empty value.
+func firstSet(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// stepper is implemented by the logger and log steps. Deployments to a
+// single target log to the root, deployments to multiple targets log each
+// target to its own step.
+type stepper interface {
+	Step(name string) *logStep
+	Infof(format string, args ...interface{})
+	Verbosef(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// deployInput is the input to a deployment, shared by all targets.
+type deployInput struct {
+	Resources resource.List
+	Sources   []sourcecode
+	User      string
+	Commit    string
+
+	// generate serializes template generation. Generating a template sets
+	// the source locations on the resources, which are shared by all
+	// targets.
+	generate *sync.Mutex
+}
+
+// deploy deploys the resources to a single target.
+func (a *App) deploy(ctx context.Context, log stepper, target deployTarget, opts DeploymentOpts, in deployInput) int {
+	srcs := in.Sources
+	if target.StackName == "" {
+		log.Errorf("Stack name not set")
 		return 2
 	}
 
-	cfg, err := external.LoadDefaultAWSConfig()
+	cfg, err := target.AWS.config()
 	if err != nil {
-		a.Log.Errorf("Could not load aws config: %v", err)
+		log.Errorf("Could not load aws config: %v", err)
+		return 1
+	}
+	if target.Account != "" {
+		account, err := accountID(ctx, cfg)
+		if err != nil {
+			log.Errorf("Could not get account: %v", err)
+			return 1
+		}
+		if account != target.Account {
+			log.Errorf("Credentials are for account %s, target requires %s", account, target.Account)
+			return 1
+		}
 	}
 	cf := cloudformation.NewClient(cfg)
-	s3 := source.NewS3(cfg, opts.SourceBucket)
+	bucket, err := sourceBucket(ctx, cf, target.SourceBucket, target.AWS)
+	if err != nil {
+		log.Errorf("Could not get bootstrap source bucket: %v", err)
+		return 1
+	}
+	if bucket != target.SourceBucket {
+		log.Verbosef("Source bucket: %s", bucket)
+		target.SourceBucket = bucket
+	}
+	if len(srcs) > 0 && target.SourceBucket == "" {
+		log.Errorf("Source bucket not set, run func bootstrap or set --source-bucket")
+		return 2
+	}
+	s3 := target.AWS.s3(cfg, target.SourceBucket)
+	cache := &source.Cache{Dir: cacheDir()}
 
-	srcStep := a.Log.Step("Process source code")
-	locs := sourceLocations(srcs, opts.SourceBucket)
-	tmpl, diags := cloudformation.Generate(resources, locs)
+	srcStep := log.Step("Process source code")
+	srcStep.Icon = true
+	if len(srcs) > 0 && target.AWS.S3Endpoint == "" {
+		// Lambda functions require the source code to be in the same region.
+		// The region is not checked for S3 compatible endpoints.
+		region, err := s3.Region(ctx)
+		if err != nil {
+			srcStep.Errorf("Could not get source bucket region: %v", err)
+			return 1
+		}
+		if region != cfg.Region {
+			srcStep.Errorf("Source bucket %s is in %s, must be in %s", target.SourceBucket, region, cfg.Region)
+			return 2
+		}
+	}
+	locs := sourceLocations(srcs, s3)
+	in.generate.Lock()
+	tmpl, diags := cloudformation.Generate(in.Resources, locs)
+	in.generate.Unlock()
 	srcStep.PrintDiags(diags, a.loader.Files())
 	if diags.HasErrors() {
 		return 1
@@ -397,7 +868,7 @@ func (a *App) DeployCloudFormation(ctx context.Context, dir string, opts Deploym
 		g.Go(func() error {
 			defer wg.Done()
 			step := srcStep.Step(src.Resource.Name)
-			if err := ensureSource(gctx, src, s3, step); err != nil {
+			if err := ensureSource(gctx, src, cache, s3, step); err != nil {
 				return fmt.Errorf("%s: %w", src.Resource.Name, err)
 			}
 			step.Done()
@@ -412,12 +883,12 @@ func (a *App) DeployCloudFormation(ctx context.Context, dir string, opts Deploym
 	// 2/2: Change set
 	var changeset *cloudformation.ChangeSet
 	g.Go(func() error {
-		stack, err := cf.StackByName(gctx, opts.StackName)
+		stack, err := cf.StackByName(gctx, target.StackName)
 		if err != nil {
 			return fmt.Errorf("get stack: %w", err)
 		}
 
-		cs, err := cf.CreateChangeSet(gctx, stack, tmpl)
+		cs, err := cf.CreateChangeSet(gctx, stack, tmpl, opts.changeSetOpts()...)
 		if err != nil {
 			return fmt.Errorf("create change set: %w", err)
 		}
@@ -427,65 +898,191 @@ func (a *App) DeployCloudFormation(ctx context.Context, dir string, opts Deploym
 	})
 
 	if err := g.Wait(); err != nil {
-		a.Log.Errorf("Error: %v", err)
+		if ctx.Err() != nil {
+			log.Errorf("Interrupted")
+		} else {
+			log.Errorf("Error: %v", err)
+		}
+		if changeset != nil {
+			// The context may have been cancelled, clean up with a new one.
+			if err := cf.DeleteChangeSet(context.Background(), changeset); err != nil {
+				log.Errorf("Error cleaning up change set: %v\n", err)
+				return 3
+			}
+		}
 		return 1
 	}
 
+	code, deployed := a.apply(ctx, log, cf, changeset, tmpl.LookupResource, opts)
+	if code != 0 || !deployed {
+		return code
+	}
+
+	if target.SourceBucket == "" {
+		log.Verbosef("Source bucket not set, deployment not recorded")
+		return 0
+	}
+	rec := &history.Record{
+		ID:        changeset.Name,
+		Stack:     target.StackName,
+		Time:      time.Now().UTC(),
+		User:      in.User,
+		Commit:    in.Commit,
+		Template:  tmpl,
+		Sources:   make(map[string]string, len(srcs)),
+		Resources: make(map[string]string, len(in.Resources)),
+	}
+	for _, src := range srcs {
+		rec.Sources[src.Resource.Name] = src.Key
+	}
+	for _, res := range in.Resources {
+		rec.Resources[cloudformation.LogicalName(res.Name)] = res.Name
+	}
+	store := &history.Store{Storage: s3}
+	if err := store.Put(ctx, rec); err != nil {
+		// The deployment itself succeeded
+		log.Errorf("Could not record deployment: %v", err)
+	}
+	return 0
+}
+
+// apply executes a change set and renders the deployment. Returns false if
+// there was nothing to deploy.
+func (a *App) apply(ctx context.Context, log stepper, cf *cloudformation.Client, changeset *cloudformation.ChangeSet, names func(string) string, opts DeploymentOpts) (int, bool) {
 	if len(changeset.Changes) == 0 {
-		a.Log.Infof(ui.Format("\nNo changes", ui.Dim))
+		log.Infof(ui.Format("\nNo changes", ui.Dim))
 		if err := cf.DeleteChangeSet(ctx, changeset); err != nil {
 			// Safe to ignore
-			a.Log.Errorf("Error cleaning up change set: %v\n", err)
-			return 3
+			log.Errorf("Error cleaning up change set: %v\n", err)
+			return 3, false
 		}
-		return 0
+		if opts.TerminationProtection && changeset.Stack.ID != "" {
+			if err := cf.SetTerminationProtection(ctx, changeset.Stack, true); err != nil {
+				log.Errorf("Could not enable termination protection: %v", err)
+				return 1, false
+			}
+		}
+		return 0, false
 	}
 
-	step = a.Log.Step("Deploy")
+	step := log.Step("Deploy")
+	step.Icon = true
 
 	deployment, err := cf.ExecuteChangeSet(ctx, changeset)
 	if err != nil {
-		a.Log.Errorf("Could not execute change set: %v", err)
-		return 1
+		log.Errorf("Could not execute change set: %v", err)
+		if ctx.Err() != nil {
+			_ = cf.DeleteChangeSet(context.Background(), changeset)
+		}
+		return 1, true
 	}
 
-	deploySteps := make(map[string]*logStep)
-	for ev := range cf.Events(ctx, deployment) {
-		switch e := ev.(type) {
-		case cloudformation.ErrorEvent:
-			step.Errorf("Deployment error: %v", e.Error)
-			return 1
-		case cloudformation.ResourceEvent:
-			name := tmpl.LookupResource(e.LogicalID)
-			if name == "" {
-				// No mapping for resources that are being deleted
-				name = e.LogicalID
-			}
-			resStep, ok := deploySteps[name]
-			if !ok {
-				resStep = step.Step(e.Operation.String() + " " + name)
-				resStep.Icon = true
-				deploySteps[name] = resStep
-			}
-			switch e.State {
-			case cloudformation.StateComplete:
-				resStep.Done()
-			case cloudformation.StateFailed:
-				resStep.Errorf("%s failed because %s", e.Operation, e.Reason)
-			}
-		case cloudformation.StackEvent:
-			if e.State == cloudformation.StateComplete {
-				if e.Operation == cloudformation.StackRollback {
-					if e.Reason != "" {
-						step.Errorf("Deployment failed: %s", e.Reason)
-					}
-					return 1
-				}
-			}
+	w := &deploymentWatcher{
+		cf:         cf,
+		deployment: deployment,
+		step:       step,
+		names:      names,
+	}
+	if code := w.watch(ctx); code != 0 {
+		if ctx.Err() == nil {
+			return code, true
+		}
+		step.Errorf("Interrupted, cancelling deployment")
+		// Keep following the rollback with a new context. Another interrupt
+		// exits immediately.
+		bg := context.Background()
+		err := cf.CancelDeployment(bg, deployment)
+		if errors.Is(err, cloudformation.ErrCreateInProgress) {
+			step.Errorf("The stack is being created and cannot be cancelled, creation continues in the background")
+			return 1, true
+		}
+		if err != nil {
+			step.Errorf("Could not cancel deployment: %v", err)
+		}
+		w.watch(bg)
+		return 1, true
+	}
+
+	if opts.TerminationProtection {
+		if err := cf.SetTerminationProtection(ctx, changeset.Stack, true); err != nil {
+			step.Errorf("Could not enable termination protection: %v", err)
+			return 1, true
 		}
 	}
 
 	step.Done()
 
+	return 0, true
+}
+
+// deploymentWatcher renders the events of a deployment as steps.
+type deploymentWatcher struct {
+	cf         *cloudformation.Client
+	deployment *cloudformation.Deployment
+	step       *logStep
+
+	// names returns the resource name for a logical id. An empty string is
+	// returned if the resource is not known.
+	names func(logicalID string) string
+
+	steps map[string]*logStep
+}
+
+// watch renders events until the deployment completes. The returned code is
+// non-zero if the deployment failed or the context was cancelled.
+func (w *deploymentWatcher) watch(ctx context.Context) int {
+	for ev := range w.cf.Events(ctx, w.deployment) {
+		if e, ok := ev.(cloudformation.ErrorEvent); ok {
+			if ctx.Err() != nil {
+				return 1
+			}
+			w.step.Errorf("Deployment error: %v", e.Error)
+			return 1
+		}
+		if code, done := w.handle(ev); done {
+			return code
+		}
+	}
+	if ctx.Err() != nil {
+		return 1
+	}
 	return 0
 }
+
+// handle renders a single event. Returns true if the event completed the
+// deployment.
+func (w *deploymentWatcher) handle(ev cloudformation.Event) (int, bool) {
+	switch e := ev.(type) {
+	case cloudformation.ResourceEvent:
+		name := w.names(e.LogicalID)
+		if name == "" {
+			// No mapping for resources that are being deleted
+			name = e.LogicalID
+		}
+		if w.steps == nil {
+			w.steps = make(map[string]*logStep)
+		}
+		resStep, ok := w.steps[name]
+		if !ok {
+			resStep = w.step.Step(e.Operation.String() + " " + name)
+			resStep.Icon = true
+			w.steps[name] = resStep
+		}
+		switch e.State {
+		case cloudformation.StateComplete:
+			resStep.Done()
+		case cloudformation.StateFailed:
+			resStep.Errorf("%s failed because %s", e.Operation, e.Reason)
+		}
+	case cloudformation.StackEvent:
+		if e.State == cloudformation.StateComplete {
+			if e.Operation == cloudformation.StackRollback {
+				if e.Reason != "" {
+					w.step.Errorf("Deployment failed: %s", e.Reason)
+				}
+				return 1, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/func/func/project"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDeploymentOpts_targets(t *testing.T) {
+	proj := &project.Project{
+		Stack:        "project-stack",
+		SourceBucket: "project-bucket",
+		Region:       "us-east-1",
+		Profile:      "project-profile",
+		Targets: []*project.Target{
+			{
+				Name: "a",
+			},
+			{
+				Name:          "b",
+				Account:       "123456789012",
+				Region:        "eu-west-1",
+				Stack:         "b-stack",
+				SourceBucket:  "b-bucket",
+				Profile:       "b-profile",
+				AssumeRoleARN: "b-role",
+			},
+			{
+				Name:   "c",
+				Region: "eu-central-1",
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		opts    DeploymentOpts
+		project *project.Project
+		want    []deployTarget
+		wantErr bool
+	}{
+		{
+			name: "NoProject",
+			opts: DeploymentOpts{StackName: "flag-stack", AWS: AWSOpts{Region: "us-west-2"}},
+			want: []deployTarget{
+				{StackName: "flag-stack", AWS: AWSOpts{Region: "us-west-2"}},
+			},
+		},
+		{
+			name:    "NoTargets",
+			opts:    DeploymentOpts{SourceBucket: "flag-bucket"},
+			project: &project.Project{Stack: "project-stack", SourceBucket: "project-bucket", Region: "us-east-1"},
+			want: []deployTarget{
+				{StackName: "project-stack", SourceBucket: "flag-bucket", AWS: AWSOpts{Region: "us-east-1"}},
+			},
+		},
+		{
+			name:    "NoTargetsSelectTarget",
+			opts:    DeploymentOpts{Target: "a"},
+			project: &project.Project{Stack: "project-stack"},
+			wantErr: true,
+		},
+		{
+			name:    "Targets",
+			project: proj,
+			want: []deployTarget{
+				{
+					Name:         "a",
+					StackName:    "project-stack",
+					SourceBucket: "project-bucket",
+					AWS:          AWSOpts{Region: "us-east-1", Profile: "project-profile"},
+				},
+				{
+					Name:         "b",
+					Account:      "123456789012",
+					StackName:    "b-stack",
+					SourceBucket: "b-bucket",
+					AWS:          AWSOpts{Region: "eu-west-1", Profile: "b-profile", AssumeRoleARN: "b-role"},
+				},
+				{
+					// Project bucket is in another region.
+					Name:      "c",
+					StackName: "project-stack",
+					AWS:       AWSOpts{Region: "eu-central-1", Profile: "project-profile"},
+				},
+			},
+		},
+		{
+			name:    "FlagWithMultipleTargets",
+			opts:    DeploymentOpts{AWS: AWSOpts{Region: "ap-south-1"}},
+			project: proj,
+			wantErr: true,
+		},
+		{
+			name:    "SelectTarget",
+			opts:    DeploymentOpts{Target: "c"},
+			project: proj,
+			want: []deployTarget{
+				{
+					Name:      "c",
+					StackName: "project-stack",
+					AWS:       AWSOpts{Region: "eu-central-1", Profile: "project-profile"},
+				},
+			},
+		},
+		{
+			name: "FlagsOverrideSelectedTarget",
+			opts: DeploymentOpts{
+				Target:       "b",
+				StackName:    "flag-stack",
+				SourceBucket: "flag-bucket",
+				AWS: AWSOpts{
+					Region:        "ap-south-1",
+					Profile:       "flag-profile",
+					AssumeRoleARN: "flag-role",
+				},
+			},
+			project: proj,
+			want: []deployTarget{
+				{
+					Name:         "b",
+					Account:      "123456789012",
+					StackName:    "flag-stack",
+					SourceBucket: "flag-bucket",
+					AWS:          AWSOpts{Region: "ap-south-1", Profile: "flag-profile", AssumeRoleARN: "flag-role"},
+				},
+			},
+		},
+		{
+			name: "FlagOverridesRegion",
+			opts: DeploymentOpts{
+				Target: "a",
+				AWS:    AWSOpts{Region: "ap-south-1"},
+			},
+			project: proj,
+			want: []deployTarget{
+				{
+					// Project bucket is not in the selected region.
+					Name:      "a",
+					StackName: "project-stack",
+					AWS:       AWSOpts{Region: "ap-south-1", Profile: "project-profile"},
+				},
+			},
+		},
+		{
+			name:    "UnknownTarget",
+			opts:    DeploymentOpts{Target: "d"},
+			project: proj,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.opts.targets(tc.project)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("targets() err = %v, want err = %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("Diff (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/external"
+	"github.com/aws/aws-sdk-go-v2/aws/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/func/func/project"
+	"github.com/func/func/source"
+)
+
+// AWSOpts contains options for connecting to AWS.
+type AWSOpts struct {
+	Profile string
+	Region  string
+
+	// Role to assume. The credentials resolved from the profile or the
+	// environment are used for assuming the role.
+	AssumeRoleARN   string
+	ExternalID      string
+	RoleSessionName string
+
+	// S3 compatible endpoint to use for the source bucket, such as a local
+	// MinIO server. Path style addressing is required by most S3 compatible
+	// services.
+	S3Endpoint  string
+	S3PathStyle bool
+}
+
+func (opts *AWSOpts) setDefaults(p *project.Project) {
+	if opts.Profile == "" {
+		opts.Profile = p.Profile
+	}
+	if opts.Region == "" {
+		opts.Region = p.Region
+	}
+}
+
+func (opts AWSOpts) config() (aws.Config, error) {
+	var cfgs []external.Config
+	if opts.Profile != "" {
+		cfgs = append(cfgs, external.WithSharedConfigProfile(opts.Profile))
+	}
+	if opts.Region != "" {
+		cfgs = append(cfgs, external.WithRegion(opts.Region))
+	}
+	cfg, err := external.LoadDefaultAWSConfig(cfgs...)
+	if err != nil {
+		return aws.Config{}, err
+	}
+	if opts.AssumeRoleARN != "" {
+		// The provider caches the credentials and refreshes them before they
+		// expire, so they remain valid for long running deployments.
+		cfg.Credentials = stscreds.NewAssumeRoleProvider(sts.New(cfg), opts.AssumeRoleARN, func(o *stscreds.AssumeRoleProviderOptions) {
+			o.RoleSessionName = opts.RoleSessionName
+			if o.RoleSessionName == "" {
+				o.RoleSessionName = "func-" + time.Now().UTC().Format("20060102-150405")
+			}
+			if opts.ExternalID != "" {
+				o.ExternalID = aws.String(opts.ExternalID)
+			}
+			o.ExpiryWindow = time.Minute
+		})
+	}
+	return cfg, nil
+}
+
+// s3 returns an S3 client for the bucket, connecting to the custom S3
+// endpoint if set.
+func (opts AWSOpts) s3(cfg aws.Config, bucket string) *source.S3 {
+	var s3opts []source.S3Opt
+	if opts.S3Endpoint != "" {
+		s3opts = append(s3opts, source.WithEndpoint(opts.S3Endpoint))
+	}
+	if opts.S3PathStyle {
+		s3opts = append(s3opts, source.WithPathStyle())
+	}
+	return source.NewS3(cfg, bucket, s3opts...)
+}
+
+// accountID returns the AWS account ID the credentials in the config belong
+// to.
+func accountID(ctx context.Context, cfg aws.Config) (string, error) {
+	resp, err := sts.New(cfg).GetCallerIdentityRequest(&sts.GetCallerIdentityInput{}).Send(ctx)
+	if err != nil {
+		return "", err
+	}
+	return *resp.Account, nil
+}
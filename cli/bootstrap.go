@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/func/func/cloudformation"
+)
+
+// bootstrapStack is the name of the stack that manages the source bucket.
+// There is one stack per account and region.
+const bootstrapStack = "func-bootstrap"
+
+// sourceKeyPrefix is the common prefix of the keys of source code archives.
+// Keys are versioned checksums, see source.ChecksumVersion. Deployment records
+// are stored under history.Prefix, which does not share the prefix.
+const sourceKeyPrefix = "v"
+
+// bootstrapBucketOutput is the output of the bootstrap stack that contains the
+// name of the source bucket.
+const bootstrapBucketOutput = "SourceBucket"
+
+// BootstrapOpts contains options for creating the source bucket.
+type BootstrapOpts struct {
+	Env string
+	AWS AWSOpts
+
+	// ExpireDays expires source code archives in the bucket after the number
+	// of days. If not set, archives are never expired. Deployment records are
+	// never expired.
+	//
+	// Deployments with expired source code cannot be rolled back to.
+	ExpireDays int
+}
+
+// bootstrapTemplate returns the template for the bootstrap stack.
+//
+// The bucket is encrypted and public access to it is blocked. Incomplete
+// uploads are removed after a day. Expiration only applies to source code
+// archives, so that deployment records are kept. The bucket is retained if the stack is
+// deleted, as it may contain source code for deployed functions.
+func bootstrapTemplate(expireDays int) *cloudformation.Template {
+	rules := []interface{}{
+		map[string]interface{}{
+			"Id":     "AbortIncompleteUploads",
+			"Status": "Enabled",
+			"AbortIncompleteMultipartUpload": map[string]interface{}{
+				"DaysAfterInitiation": 1,
+			},
+		},
+	}
+	if expireDays > 0 {
+		rules = append(rules, map[string]interface{}{
+			"Id":               "ExpireSourceCode",
+			"Status":           "Enabled",
+			"Prefix":           sourceKeyPrefix,
+			"ExpirationInDays": expireDays,
+		})
+	}
+	return &cloudformation.Template{
+		AWSTemplateFormatVersion: "2010-09-09",
+		Description:              "Source code bucket for func deployments",
+		Resources: map[string]cloudformation.Resource{
+			"SourceBucket": {
+				Type:           "AWS::S3::Bucket",
+				DeletionPolicy: "Retain",
+				Properties: map[string]interface{}{
+					"BucketName": map[string]interface{}{
+						"Fn::Sub": "func-source-${AWS::AccountId}-${AWS::Region}",
+					},
+					"BucketEncryption": map[string]interface{}{
+						"ServerSideEncryptionConfiguration": []interface{}{
+							map[string]interface{}{
+								"ServerSideEncryptionByDefault": map[string]interface{}{
+									"SSEAlgorithm": "AES256",
+								},
+							},
+						},
+					},
+					"PublicAccessBlockConfiguration": map[string]interface{}{
+						"BlockPublicAcls":       true,
+						"BlockPublicPolicy":     true,
+						"IgnorePublicAcls":      true,
+						"RestrictPublicBuckets": true,
+					},
+					"LifecycleConfiguration": map[string]interface{}{
+						"Rules": rules,
+					},
+				},
+			},
+		},
+		Outputs: map[string]cloudformation.Output{
+			bootstrapBucketOutput: {
+				Description: "Bucket to store source code in",
+				Value:       map[string]interface{}{"Ref": "SourceBucket"},
+			},
+		},
+	}
+}
+
+// stackReader reads deployed stacks. It is implemented by
+// cloudformation.Client.
+type stackReader interface {
+	StackByName(ctx context.Context, name string) (*cloudformation.Stack, error)
+	StackOutputs(ctx context.Context, stack *cloudformation.Stack) (map[string]string, error)
+}
+
+// bootstrapBucket returns the name of the source bucket created by Bootstrap.
+// An empty string is returned if the bootstrap stack does not exist.
+func bootstrapBucket(ctx context.Context, cf stackReader) (string, error) {
+	stack, err := cf.StackByName(ctx, bootstrapStack)
+	if err != nil {
+		return "", err
+	}
+	if stack == nil || stack.ID == "" {
+		return "", nil
+	}
+	outputs, err := cf.StackOutputs(ctx, stack)
+	if err != nil {
+		return "", err
+	}
+	return outputs[bootstrapBucketOutput], nil
+}
+
+// sourceBucket returns the bucket to store source code in. If bucket is not
+// set, the bucket created by Bootstrap is used. The bootstrap bucket is not
+// used with a custom S3 endpoint, as it only exists in AWS.
+func sourceBucket(ctx context.Context, cf stackReader, bucket string, opts AWSOpts) (string, error) {
+	if bucket != "" || opts.S3Endpoint != "" {
+		return bucket, nil
+	}
+	return bootstrapBucket(ctx, cf)
+}
+
+// Bootstrap creates or updates a stack with a source bucket for the account
+// and region. Deployments use the bucket if no source bucket is set.
+func (a *App) Bootstrap(ctx context.Context, dir string, opts BootstrapOpts) int {
+	defer func() {
+		// Better way to ensure render completes
+		time.Sleep(200 * time.Millisecond)
+	}()
+
+	step := a.Log.Step("Prepare bootstrap stack")
+	proj, _, ok := a.loadProject(dir, opts.Env, step)
+	if !ok {
+		return 1
+	}
+	if proj != nil {
+		opts.AWS.setDefaults(proj)
+	}
+	cfg, err := opts.AWS.config()
+	if err != nil {
+		step.Errorf("Could not load aws config: %v", err)
+		return 1
+	}
+	step.Verbosef("Region: %s", cfg.Region)
+	cf := cloudformation.NewClient(cfg)
+
+	stack, err := cf.StackByName(ctx, bootstrapStack)
+	if err != nil {
+		step.Errorf("Could not get stack: %v", err)
+		return 1
+	}
+	tmpl := bootstrapTemplate(opts.ExpireDays)
+	changeset, err := cf.CreateChangeSet(ctx, stack, tmpl)
+	if err != nil {
+		step.Errorf("Could not create change set: %v", err)
+		return 1
+	}
+	step.Done()
+
+	// The bootstrap stack is not generated from resources, logical ids are
+	// shown as is.
+	names := func(logicalID string) string { return logicalID }
+	if code, _ := a.apply(ctx, a.Log, cf, changeset, names, DeploymentOpts{}); code != 0 {
+		return code
+	}
+
+	bucket, err := bootstrapBucket(ctx, cf)
+	if err != nil {
+		a.Log.Errorf("Could not get source bucket: %v", err)
+		return 1
+	}
+	fmt.Fprintf(a.Stdout, "Source bucket: %s\n", bucket)
+	return 0
+}
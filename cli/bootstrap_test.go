@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/func/func/cloudformation"
+	"github.com/func/func/history"
+	"github.com/func/func/source"
+	"github.com/google/go-cmp/cmp"
+)
+
+type mockStacks struct {
+	stack   *cloudformation.Stack
+	outputs map[string]string
+	err     error
+
+	calls int
+}
+
+func (m *mockStacks) StackByName(ctx context.Context, name string) (*cloudformation.Stack, error) {
+	m.calls++
+	if name != bootstrapStack {
+		return nil, errors.New("unexpected stack " + name)
+	}
+	return m.stack, m.err
+}
+
+func (m *mockStacks) StackOutputs(ctx context.Context, stack *cloudformation.Stack) (map[string]string, error) {
+	return m.outputs, nil
+}
+
+func TestBootstrapTemplate(t *testing.T) {
+	abort := map[string]interface{}{
+		"Id":     "AbortIncompleteUploads",
+		"Status": "Enabled",
+		"AbortIncompleteMultipartUpload": map[string]interface{}{
+			"DaysAfterInitiation": 1,
+		},
+	}
+	tests := []struct {
+		name       string
+		expireDays int
+		want       []interface{}
+	}{
+		{
+			name: "NoExpiration",
+			want: []interface{}{abort},
+		},
+		{
+			name:       "Expiration",
+			expireDays: 30,
+			want: []interface{}{
+				abort,
+				map[string]interface{}{
+					"Id":               "ExpireSourceCode",
+					"Prefix":           "v",
+					"Status":           "Enabled",
+					"ExpirationInDays": 30,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := bootstrapTemplate(tc.expireDays)
+			bucket, ok := tmpl.Resources["SourceBucket"]
+			if !ok {
+				t.Fatalf("SourceBucket not in template")
+			}
+			if bucket.DeletionPolicy != "Retain" {
+				t.Errorf("DeletionPolicy = %q, want %q", bucket.DeletionPolicy, "Retain")
+			}
+			got := bucket.Properties["LifecycleConfiguration"].(map[string]interface{})["Rules"]
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("Diff (-got +want)\n%s", diff)
+			}
+			output := tmpl.Outputs[bootstrapBucketOutput].Value
+			if diff := cmp.Diff(output, map[string]interface{}{"Ref": "SourceBucket"}); diff != "" {
+				t.Errorf("Output diff (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSourceKeyPrefix(t *testing.T) {
+	// Source code archives are expired by prefix, deployment records must not
+	// be.
+	if !strings.HasPrefix(source.ChecksumVersion, sourceKeyPrefix) {
+		t.Errorf("Checksum version %q does not start with %q", source.ChecksumVersion, sourceKeyPrefix)
+	}
+	if strings.HasPrefix(history.Prefix, sourceKeyPrefix) {
+		t.Errorf("History prefix %q starts with %q", history.Prefix, sourceKeyPrefix)
+	}
+}
+
+func TestBootstrapBucket(t *testing.T) {
+	tests := []struct {
+		name    string
+		stacks  *mockStacks
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "NotFound",
+			stacks: &mockStacks{},
+			want:   "",
+		},
+		{
+			name:   "NotCreated",
+			stacks: &mockStacks{stack: &cloudformation.Stack{Name: bootstrapStack}},
+			want:   "",
+		},
+		{
+			name: "NoOutput",
+			stacks: &mockStacks{
+				stack:   &cloudformation.Stack{Name: bootstrapStack, ID: "id"},
+				outputs: map[string]string{},
+			},
+			want: "",
+		},
+		{
+			name: "Output",
+			stacks: &mockStacks{
+				stack:   &cloudformation.Stack{Name: bootstrapStack, ID: "id"},
+				outputs: map[string]string{bootstrapBucketOutput: "func-source-123-eu-west-1"},
+			},
+			want: "func-source-123-eu-west-1",
+		},
+		{
+			name:    "Error",
+			stacks:  &mockStacks{err: errors.New("err")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := bootstrapBucket(context.Background(), tc.stacks)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("bootstrapBucket() err = %v, want err = %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("bootstrapBucket() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSourceBucket(t *testing.T) {
+	bootstrapped := func() *mockStacks {
+		return &mockStacks{
+			stack:   &cloudformation.Stack{Name: bootstrapStack, ID: "id"},
+			outputs: map[string]string{bootstrapBucketOutput: "bootstrap-bucket"},
+		}
+	}
+	tests := []struct {
+		name      string
+		stacks    *mockStacks
+		bucket    string
+		opts      AWSOpts
+		want      string
+		wantCalls int
+	}{
+		{
+			name:      "Set",
+			stacks:    bootstrapped(),
+			bucket:    "bucket",
+			want:      "bucket",
+			wantCalls: 0,
+		},
+		{
+			name:      "Discover",
+			stacks:    bootstrapped(),
+			want:      "bootstrap-bucket",
+			wantCalls: 1,
+		},
+		{
+			name:      "NotBootstrapped",
+			stacks:    &mockStacks{},
+			want:      "",
+			wantCalls: 1,
+		},
+		{
+			name:      "Endpoint",
+			stacks:    bootstrapped(),
+			opts:      AWSOpts{S3Endpoint: "http://localhost:9000"},
+			want:      "",
+			wantCalls: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := sourceBucket(context.Background(), tc.stacks, tc.bucket, tc.opts)
+			if err != nil {
+				t.Fatalf("sourceBucket() err = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("sourceBucket() = %q, want %q", got, tc.want)
+			}
+			if tc.stacks.calls != tc.wantCalls {
+				t.Errorf("Got %d stack lookups, want %d", tc.stacks.calls, tc.wantCalls)
+			}
+		})
+	}
+}
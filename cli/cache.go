@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/func/func/source"
+)
+
+// CachePruneOpts contains options for pruning the local build cache.
+type CachePruneOpts struct {
+	// OlderThan only removes entries that have not been used within the
+	// duration. If not set, all entries are removed.
+	OlderThan time.Duration
+}
+
+// PruneCache removes built source code archives and installed dependencies
+// from the local cache.
+func (a *App) PruneCache(opts CachePruneOpts) int {
+	defer func() {
+		// Better way to ensure render completes
+		time.Sleep(100 * time.Millisecond)
+	}()
+
+	dir := cacheDir()
+	step := a.Log.Step("Prune cache")
+	step.Verbosef("Dir: %s", dir)
+	cache := &source.Cache{Dir: dir}
+	res, err := cache.Prune(opts.OlderThan)
+	if err != nil {
+		step.Errorf("Could not prune cache: %v", err)
+		return 1
+	}
+	if res.Legacy > 0 {
+		step.Verbosef("Removed %d archives with checksums from an earlier version", res.Legacy)
+	}
+	step.Infof("Removed %d entries, freed %s", res.Removed, byteSize(res.Size))
+	step.Done()
+	return 0
+}
+
+// byteSize formats a size in bytes for display.
+func byteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/func/func/cloudformation"
+)
+
+// ConvertOpts contains options for converting a CloudFormation template.
+type ConvertOpts struct {
+	// Output is the file to write the resource configurations to. If not set,
+	// the configurations are written to stdout.
+	Output string
+}
+
+// Convert converts a CloudFormation template to resource configurations.
+// Resources and properties that cannot be converted are added as comments.
+func (a *App) Convert(file string, opts ConvertOpts) int {
+	body, err := ioutil.ReadFile(file)
+	if err != nil {
+		a.Log.Errorf("Could not read template: %v", err)
+		return 1
+	}
+	out, err := cloudformation.Convert(body, registry())
+	if err != nil {
+		a.Log.Errorf("Could not convert %s: %v", filepath.Base(file), err)
+		return 1
+	}
+	if opts.Output == "" {
+		if _, err := a.Stdout.Write(out); err != nil {
+			a.Log.Errorf(err.Error())
+			return 1
+		}
+		return 0
+	}
+	if err := ioutil.WriteFile(opts.Output, out, 0644); err != nil {
+		a.Log.Errorf("Could not write output: %v", err)
+		return 1
+	}
+	a.Log.Infof("Wrote %s", opts.Output)
+	return 0
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around changes.
+const diffContext = 3
+
+// diffOp is a line in a diff.
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+	a, b int // Zero based line numbers in a and b before the op
+}
+
+// unifiedDiff returns a unified diff between a and b. The file name headers
+// are not included. An empty diff is returned if a and b are equal.
+//
+// The diff is computed from the longest common subsequence of lines, which is
+// quadratic in the number of lines. It is intended for configuration files.
+func unifiedDiff(a, b []byte) []byte {
+	al, bl := splitLines(a), splitLines(b)
+
+	// lcs[i][j] is the length of the longest common subsequence of al[i:] and
+	// bl[j:].
+	lcs := make([][]int, len(al)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bl)+1)
+	}
+	for i := len(al) - 1; i >= 0; i-- {
+		for j := len(bl) - 1; j >= 0; j-- {
+			switch {
+			case al[i] == bl[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(al) || j < len(bl) {
+		switch {
+		case i < len(al) && j < len(bl) && al[i] == bl[j]:
+			ops = append(ops, diffOp{kind: ' ', line: al[i], a: i, b: j})
+			i++
+			j++
+		case j == len(bl) || (i < len(al) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{kind: '-', line: al[i], a: i, b: j})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', line: bl[j], a: i, b: j})
+			j++
+		}
+	}
+
+	var buf bytes.Buffer
+	for start := 0; start < len(ops); {
+		// Find the next change.
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+		// Extend the hunk until there are more unchanged lines than fit in
+		// the context of two hunks.
+		end := start
+		for k := start; k < len(ops); k++ {
+			if ops[k].kind != ' ' {
+				end = k + 1
+				continue
+			}
+			if k-end >= 2*diffContext {
+				break
+			}
+		}
+		from := start - diffContext
+		if from < 0 {
+			from = 0
+		}
+		to := end + diffContext
+		if to > len(ops) {
+			to = len(ops)
+		}
+		writeHunk(&buf, ops[from:to])
+		start = to
+	}
+	return buf.Bytes()
+}
+
+func writeHunk(buf *bytes.Buffer, ops []diffOp) {
+	var na, nb int
+	for _, op := range ops {
+		if op.kind != '+' {
+			na++
+		}
+		if op.kind != '-' {
+			nb++
+		}
+	}
+	fmt.Fprintf(buf, "@@ -%s +%s @@\n", hunkRange(ops[0].a, na), hunkRange(ops[0].b, nb))
+	for _, op := range ops {
+		buf.WriteByte(op.kind)
+		buf.WriteString(op.line)
+		if !strings.HasSuffix(op.line, "\n") {
+			buf.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats the start line and number of lines of a hunk. Empty
+// ranges start at the line before the hunk.
+func hunkRange(start, n int) string {
+	switch n {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, n)
+	}
+}
+
+// splitLines splits data into lines, including the line endings.
+func splitLines(data []byte) []string {
+	var out []string
+	for len(data) > 0 {
+		n := bytes.IndexByte(data, '\n')
+		if n < 0 {
+			out = append(out, string(data))
+			break
+		}
+		out = append(out, string(data[:n+1]))
+		data = data[n+1:]
+	}
+	return out
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "Equal",
+			a:    "a\nb\n",
+			b:    "a\nb\n",
+			want: "",
+		},
+		{
+			name: "Change",
+			a:    "resource \"a\" {\n  type=\"b\"\n}\n",
+			b:    "resource \"a\" {\n  type = \"b\"\n}\n",
+			want: "@@ -1,3 +1,3 @@\n" +
+				" resource \"a\" {\n" +
+				"-  type=\"b\"\n" +
+				"+  type = \"b\"\n" +
+				" }\n",
+		},
+		{
+			name: "Context",
+			a:    "1\n2\n3\n4\nx\n5\n6\n7\n8\n",
+			b:    "1\n2\n3\n4\n5\n6\n7\n8\n",
+			want: "@@ -2,7 +2,6 @@\n" +
+				" 2\n" +
+				" 3\n" +
+				" 4\n" +
+				"-x\n" +
+				" 5\n" +
+				" 6\n" +
+				" 7\n",
+		},
+		{
+			name: "MultipleHunks",
+			a:    "x\n1\n2\n3\n4\n5\n6\n7\ny\n",
+			b:    "1\n2\n3\n4\n5\n6\n7\n",
+			want: "@@ -1,4 +1,3 @@\n" +
+				"-x\n" +
+				" 1\n" +
+				" 2\n" +
+				" 3\n" +
+				"@@ -6,4 +5,3 @@\n" +
+				" 5\n" +
+				" 6\n" +
+				" 7\n" +
+				"-y\n",
+		},
+		{
+			name: "MergedHunks",
+			a:    "x\n1\n2\n3\n4\n5\n6\ny\n",
+			b:    "1\n2\n3\n4\n5\n6\n",
+			want: "@@ -1,8 +1,6 @@\n" +
+				"-x\n" +
+				" 1\n" +
+				" 2\n" +
+				" 3\n" +
+				" 4\n" +
+				" 5\n" +
+				" 6\n" +
+				"-y\n",
+		},
+		{
+			name: "Empty",
+			a:    "",
+			b:    "a\n",
+			want: "@@ -0,0 +1 @@\n" +
+				"+a\n",
+		},
+		{
+			name: "NoNewlineAtEnd",
+			a:    "a\nb",
+			b:    "a\nb\n",
+			want: "@@ -1,2 +1,2 @@\n" +
+				" a\n" +
+				"-b\n" +
+				"\\ No newline at end of file\n" +
+				"+b\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := string(unifiedDiff([]byte(tc.a), []byte(tc.b)))
+			if got != tc.want {
+				t.Errorf("Diff does not match\nGot:\n%s\nWant:\n%s", got, tc.want)
+			}
+		})
+	}
+}
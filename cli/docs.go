@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/func/func/resource"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+// Types prints all supported resource types.
+func (a *App) Types() int {
+	for _, typename := range registry().Types() {
+		fmt.Fprintln(a.Stdout, typename)
+	}
+	return 0
+}
+
+// DocsOpts contains options for printing resource documentation.
+type DocsOpts struct {
+	// JSON prints a JSON Schema of the resources instead of text.
+	JSON bool
+
+	// MarkdownDir writes a markdown reference page for each resource type to
+	// the directory instead of printing text. An index of the pages is
+	// written to README.md.
+	MarkdownDir string
+}
+
+// Docs prints the documentation for the given resource types.
+//
+// In JSON mode, the output is a JSON Schema for a single resource type, or a
+// JSON object keyed by type name if multiple or no types are given. If no
+// types are given, all types are included.
+func (a *App) Docs(types []string, opts DocsOpts) int {
+	reg := registry()
+	if len(types) == 0 {
+		if !opts.JSON && opts.MarkdownDir == "" {
+			a.Log.Errorf("Resource type not set")
+			return 2
+		}
+		types = reg.Types()
+	}
+
+	schemas := make([]*resource.Schema, len(types))
+	for i, typename := range types {
+		s, err := reg.Schema(typename)
+		if err != nil {
+			a.Log.Errorf("%v", err)
+			return 2
+		}
+		schemas[i] = s
+	}
+
+	if opts.MarkdownDir != "" {
+		if err := writeMarkdown(opts.MarkdownDir, schemas); err != nil {
+			a.Log.Errorf("Could not write docs: %v", err)
+			return 1
+		}
+		return 0
+	}
+
+	if opts.JSON {
+		var v interface{}
+		if len(schemas) == 1 {
+			v = schemas[0].JSONSchema()
+		} else {
+			all := make(map[string]interface{}, len(schemas))
+			for _, s := range schemas {
+				all[s.Type] = s.JSONSchema()
+			}
+			v = all
+		}
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			a.Log.Errorf(err.Error())
+			return 1
+		}
+		fmt.Fprintln(a.Stdout, string(out))
+		return 0
+	}
+
+	for i, s := range schemas {
+		if i > 0 {
+			fmt.Fprintln(a.Stdout)
+		}
+		printSchema(a.Stdout, s)
+	}
+	return 0
+}
+
+// writeMarkdown writes a markdown page for each schema to dir, named after the
+// type, and an index of the pages.
+func writeMarkdown(dir string, schemas []*resource.Schema) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	var index bytes.Buffer
+	fmt.Fprintf(&index, "# Resource types\n\n")
+	for _, s := range schemas {
+		name := strings.ReplaceAll(s.Type, ":", "_") + ".md"
+		var buf bytes.Buffer
+		if err := s.Markdown(&buf); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(&index, "- [%s](%s)\n", s.Type, name)
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "README.md"), index.Bytes(), 0644)
+}
+
+func printSchema(w io.Writer, s *resource.Schema) {
+	fmt.Fprintln(w, s.Type)
+	if s.Doc != "" {
+		fmt.Fprintln(w)
+		printDoc(w, s.Doc, 2)
+	}
+	fmt.Fprintln(w, "\nInputs:")
+	printAttributes(w, s.Inputs, 2, true)
+	if len(s.Outputs) > 0 {
+		fmt.Fprintln(w, "\nOutputs:")
+		printAttributes(w, s.Outputs, 2, false)
+	}
+}
+
+func printAttributes(w io.Writer, attrs []resource.Attribute, indent int, input bool) {
+	for _, attr := range attrs {
+		fmt.Fprintf(w, "\n%s%s (%s", strings.Repeat(" ", indent), attr.Name, attr.Type)
+		if input {
+			if attr.Required {
+				fmt.Fprint(w, ", required")
+			} else {
+				fmt.Fprint(w, ", optional")
+			}
+		}
+		fmt.Fprintln(w, ")")
+		if attr.Doc != "" {
+			printDoc(w, attr.Doc, indent+4)
+		}
+		printAttributes(w, attr.Attributes, indent+2, input)
+	}
+}
+
+func printDoc(w io.Writer, doc string, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, line := range strings.Split(wordwrap.WrapString(doc, uint(80-indent)), "\n") {
+		if line == "" {
+			fmt.Fprintln(w)
+			continue
+		}
+		fmt.Fprintln(w, pad+line)
+	}
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/func/func/cloudformation"
+	"github.com/func/func/project"
+)
+
+// DriftOpts contains options for detecting drift.
+type DriftOpts struct {
+	StackName string
+	Env       string
+	Format    string
+	AWS       AWSOpts
+}
+
+func (opts *DriftOpts) setDefaults(p *project.Project) {
+	if opts.StackName == "" {
+		opts.StackName = p.Stack
+	}
+	opts.AWS.setDefaults(p)
+}
+
+// DriftDetected is the exit code returned from DetectDrift when the stack
+// has drifted.
+const DriftDetected = 4
+
+// driftReport is the JSON output of drift detection.
+type driftReport struct {
+	Stack     string          `json:"stack"`
+	Status    string          `json:"status"`
+	Reason    string          `json:"reason,omitempty"`
+	Resources []resourceDrift `json:"resources"`
+}
+
+type resourceDrift struct {
+	Name string `json:"name"`
+	cloudformation.ResourceDrift
+}
+
+// DetectDrift detects changes made to deployed resources outside of
+// CloudFormation. Returns DriftDetected if any resource has drifted.
+func (a *App) DetectDrift(ctx context.Context, dir string, opts DriftOpts) int {
+	format := strings.ToLower(opts.Format)
+	if format != "text" && format != "json" {
+		a.Log.Errorf("Unsupported output format %q. Supported: [text, json]", opts.Format)
+		return 2
+	}
+
+	step := a.Log.Step("Load resource configurations")
+	proj, dir, ok := a.loadProject(dir, opts.Env, step)
+	if !ok {
+		return 1
+	}
+	if proj != nil {
+		opts.setDefaults(proj)
+	}
+	if opts.StackName == "" {
+		step.Errorf("Stack name not set")
+		return 2
+	}
+
+	// The local configuration is only used to map logical ids back to
+	// resource names. Drift is detected against the deployed template, so
+	// errors are not fatal.
+	names := make(map[string]string)
+	if resources, diags := a.loadResources(dir, proj); !diags.HasErrors() {
+		for _, res := range resources {
+			names[cloudformation.LogicalName(res.Name)] = res.Name
+		}
+	}
+	step.Done()
+
+	cfg, err := opts.AWS.config()
+	if err != nil {
+		a.Log.Errorf("Could not load aws config: %v", err)
+		return 1
+	}
+	cf := cloudformation.NewClient(cfg)
+
+	step = a.Log.Step("Detect drift")
+	stack, err := cf.StackByName(ctx, opts.StackName)
+	if err != nil {
+		step.Errorf("Could not get stack: %v", err)
+		return 1
+	}
+	if stack == nil || stack.ID == "" {
+		step.Errorf("Stack %s does not exist", opts.StackName)
+		return 1
+	}
+	drift, err := cf.DetectDrift(ctx, stack)
+	if err != nil {
+		step.Errorf("Could not detect drift: %v", err)
+		return 1
+	}
+	step.Done()
+
+	report := driftReport{
+		Stack:     stack.Name,
+		Status:    drift.Status,
+		Reason:    drift.Reason,
+		Resources: make([]resourceDrift, len(drift.Resources)),
+	}
+	for i, res := range drift.Resources {
+		name, ok := names[res.LogicalID]
+		if !ok {
+			// Resource not in local configuration
+			name = res.LogicalID
+		}
+		report.Resources[i] = resourceDrift{Name: name, ResourceDrift: res}
+	}
+
+	// Better way to ensure render completes
+	time.Sleep(100 * time.Millisecond)
+
+	if format == "json" {
+		out, err := json.MarshalIndent(report, "", "    ")
+		if err != nil {
+			a.Log.Errorf(err.Error())
+			return 1
+		}
+		fmt.Fprintln(a.Stdout, string(out))
+	} else {
+		a.printDrift(report)
+	}
+
+	if drift.Drifted() {
+		return DriftDetected
+	}
+	return 0
+}
+
+func (a *App) printDrift(report driftReport) {
+	fmt.Fprintf(a.Stdout, "Stack %s: %s\n", report.Stack, report.Status)
+	if report.Reason != "" {
+		fmt.Fprintf(a.Stdout, "%s\n", report.Reason)
+	}
+	for _, res := range report.Resources {
+		switch res.Status {
+		case "IN_SYNC":
+			continue
+		case "NOT_CHECKED":
+			if !a.Log.Verbose {
+				continue
+			}
+		}
+		fmt.Fprintf(a.Stdout, "\n  %s (%s): %s\n", res.Name, res.Type, res.Status)
+		for _, d := range res.Differences {
+			switch d.Type {
+			case "ADD":
+				fmt.Fprintf(a.Stdout, "    %s: added %s\n", d.Path, d.Actual)
+			case "REMOVE":
+				fmt.Fprintf(a.Stdout, "    %s: removed, expected %s\n", d.Path, d.Expected)
+			default:
+				fmt.Fprintf(a.Stdout, "    %s: expected %s, actual %s\n", d.Path, d.Expected, d.Actual)
+			}
+		}
+	}
+}
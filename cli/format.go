@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/func/func/project"
+	"github.com/func/func/resource"
+	"github.com/hashicorp/hcl/v2"
+)
+
+// FormatOpts contains options for formatting configuration files.
+type FormatOpts struct {
+	// Check only lists files that are not formatted, the files are not
+	// written.
+	Check bool
+
+	// Diff prints the changes to the files.
+	Diff bool
+}
+
+// Format formats all resource configuration files in the project in canonical
+// style. The names of changed files are printed.
+//
+// If Check is set, the files are not written and 1 is returned if any file is
+// not formatted.
+func (a *App) Format(dir string, opts FormatOpts) int {
+	defer func() {
+		// Better way to ensure render completes
+		time.Sleep(100 * time.Millisecond)
+	}()
+
+	if a.projects == nil {
+		a.projects = &project.Loader{}
+	}
+	// Syntax errors in the project file are reported when it is formatted.
+	if proj, _ := a.projects.Find(dir); proj != nil {
+		dir = proj.Dir
+	}
+
+	files, err := resource.ConfigFiles(dir)
+	if err != nil {
+		a.Log.Errorf("Could not read config files: %v", err)
+		return 1
+	}
+
+	code := 0
+	for _, file := range files {
+		src, err := ioutil.ReadFile(file)
+		if err != nil {
+			a.Log.Errorf("Could not read %s: %v", file, err)
+			code = 1
+			continue
+		}
+		out, diags := resource.Format(src, file)
+		if diags.HasErrors() {
+			step := a.Log.Step(file)
+			step.PrintDiags(diags, map[string]*hcl.File{file: {Bytes: src}})
+			code = 1
+			continue
+		}
+		if bytes.Equal(src, out) {
+			continue
+		}
+
+		name := file
+		if rel, err := filepath.Rel(dir, file); err == nil {
+			name = rel
+		}
+		fmt.Fprintln(a.Stdout, name)
+		if opts.Diff {
+			fmt.Fprintf(a.Stdout, "--- %s\n+++ %s\n", name, name)
+			_, _ = a.Stdout.Write(unifiedDiff(src, out))
+		}
+		if opts.Check {
+			code = 1
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			a.Log.Errorf("Could not write %s: %v", name, err)
+			code = 1
+			continue
+		}
+		if err := ioutil.WriteFile(file, out, info.Mode()); err != nil {
+			a.Log.Errorf("Could not write %s: %v", name, err)
+			code = 1
+		}
+	}
+	return code
+}
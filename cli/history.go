@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/func/func/cloudformation"
+	"github.com/func/func/history"
+	"github.com/func/func/project"
+)
+
+// currentUser returns the name of the user running the deployment.
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// gitCommit returns the git commit the directory is at. A "-dirty" suffix is
+// added if there are uncommitted changes. Returns an empty string if the
+// directory is not in a git repository.
+func gitCommit(ctx context.Context, dir string) string {
+	git := func(args ...string) (string, error) {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		return strings.TrimSpace(string(out)), err
+	}
+	commit, err := git("rev-parse", "HEAD")
+	if err != nil {
+		return ""
+	}
+	if status, err := git("status", "--porcelain"); err == nil && status != "" {
+		commit += "-dirty"
+	}
+	return commit
+}
+
+// HistoryOpts contains options for listing deployments.
+type HistoryOpts struct {
+	StackName    string
+	Env          string
+	SourceBucket string
+	AWS          AWSOpts
+	Limit        int
+}
+
+func (opts *HistoryOpts) setDefaults(p *project.Project) {
+	if opts.StackName == "" {
+		opts.StackName = p.Stack
+	}
+	if opts.SourceBucket == "" {
+		opts.SourceBucket = p.SourceBucket
+	}
+	opts.AWS.setDefaults(p)
+}
+
+// loadStackProject loads the project and applies its defaults to the
+// options. Projects with deployment targets are not supported, as records are
+// kept per stack.
+func (a *App) loadStackProject(dir, env string, opts interface{ setDefaults(*project.Project) }) int {
+	step := a.Log.Step("Load project")
+	proj, _, ok := a.loadProject(dir, env, step)
+	if !ok {
+		return 1
+	}
+	if proj != nil {
+		if len(proj.Targets) > 0 {
+			step.Errorf("Not supported for projects with deployment targets")
+			return 2
+		}
+		opts.setDefaults(proj)
+	}
+	step.Done()
+	return 0
+}
+
+// History lists previous deployments of the stack.
+func (a *App) History(ctx context.Context, dir string, opts HistoryOpts) int {
+	if code := a.loadStackProject(dir, opts.Env, &opts); code != 0 {
+		return code
+	}
+	if opts.StackName == "" {
+		a.Log.Errorf("Stack name not set")
+		return 2
+	}
+
+	cfg, err := opts.AWS.config()
+	if err != nil {
+		a.Log.Errorf("Could not load aws config: %v", err)
+		return 1
+	}
+	bucket, err := sourceBucket(ctx, cloudformation.NewClient(cfg), opts.SourceBucket, opts.AWS)
+	if err != nil {
+		a.Log.Errorf("Could not get bootstrap source bucket: %v", err)
+		return 1
+	}
+	if bucket == "" {
+		a.Log.Errorf("Source bucket not set, run func bootstrap or set --source-bucket")
+		return 2
+	}
+	if bucket != opts.SourceBucket {
+		a.Log.Verbosef("Source bucket: %s", bucket)
+	}
+	store := &history.Store{Storage: opts.AWS.s3(cfg, bucket)}
+	records, err := store.List(ctx, opts.StackName, opts.Limit)
+	if err != nil {
+		a.Log.Errorf("Could not list deployments: %v", err)
+		return 1
+	}
+
+	// Better way to ensure render completes
+	time.Sleep(100 * time.Millisecond)
+
+	if len(records) == 0 {
+		fmt.Fprintf(a.Stdout, "No deployments recorded for %s\n", opts.StackName)
+		return 0
+	}
+	tw := tabwriter.NewWriter(a.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTIME\tUSER\tCOMMIT\tNOTE")
+	for _, rec := range records {
+		note := ""
+		if rec.Rollback != "" {
+			note = "Rollback to " + rec.Rollback
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			rec.ID,
+			rec.Time.Local().Format(time.RFC3339),
+			rec.User,
+			rec.Commit,
+			note,
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		a.Log.Errorf(err.Error())
+		return 1
+	}
+	return 0
+}
+
+// RollbackOpts contains options for rolling back to a previous deployment.
+type RollbackOpts struct {
+	DeploymentOpts
+
+	// To is the id of the deployment to roll back to.
+	To string
+}
+
+// Rollback redeploys a previously recorded deployment. The source code is not
+// processed again, the previously uploaded artifacts are used.
+func (a *App) Rollback(ctx context.Context, dir string, opts RollbackOpts) int {
+	defer func() {
+		// Better way to ensure render completes
+		time.Sleep(200 * time.Millisecond)
+	}()
+
+	if code := a.loadStackProject(dir, opts.Env, &opts.DeploymentOpts); code != 0 {
+		return code
+	}
+	if opts.StackName == "" {
+		a.Log.Errorf("Stack name not set")
+		return 2
+	}
+
+	cfg, err := opts.AWS.config()
+	if err != nil {
+		a.Log.Errorf("Could not load aws config: %v", err)
+		return 1
+	}
+	cf := cloudformation.NewClient(cfg)
+	bucket, err := sourceBucket(ctx, cf, opts.SourceBucket, opts.AWS)
+	if err != nil {
+		a.Log.Errorf("Could not get bootstrap source bucket: %v", err)
+		return 1
+	}
+	if bucket == "" {
+		a.Log.Errorf("Source bucket not set, run func bootstrap or set --source-bucket")
+		return 2
+	}
+	if bucket != opts.SourceBucket {
+		a.Log.Verbosef("Source bucket: %s", bucket)
+	}
+	s3 := opts.AWS.s3(cfg, bucket)
+	store := &history.Store{Storage: s3}
+
+	step := a.Log.Step("Load deployment " + opts.To)
+	rec, err := store.Get(ctx, opts.StackName, opts.To)
+	if err != nil {
+		step.Errorf("Could not load deployment: %v", err)
+		return 1
+	}
+	names := make([]string, 0, len(rec.Sources))
+	for name := range rec.Sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key := rec.Sources[name]
+		exists, err := s3.Has(ctx, key)
+		if err != nil {
+			step.Errorf("Could not check source for %s: %v", name, err)
+			return 1
+		}
+		if !exists {
+			step.Errorf("Source for %s no longer exists: %s", name, key)
+			return 1
+		}
+	}
+	step.Done()
+
+	stack, err := cf.StackByName(ctx, opts.StackName)
+	if err != nil {
+		a.Log.Errorf("Could not get stack: %v", err)
+		return 1
+	}
+	csOpts := append(opts.changeSetOpts(), cloudformation.WithDescription("Rollback to "+rec.ID))
+	changeset, err := cf.CreateChangeSet(ctx, stack, rec.Template, csOpts...)
+	if err != nil {
+		a.Log.Errorf("Could not create change set: %v", err)
+		return 1
+	}
+
+	lookup := func(logicalID string) string { return rec.Resources[logicalID] }
+	code, deployed := a.apply(ctx, a.Log, cf, changeset, lookup, opts.DeploymentOpts)
+	if code != 0 || !deployed {
+		return code
+	}
+
+	next := &history.Record{
+		ID:        changeset.Name,
+		Stack:     opts.StackName,
+		Time:      time.Now().UTC(),
+		User:      currentUser(),
+		Commit:    rec.Commit,
+		Rollback:  rec.ID,
+		Template:  rec.Template,
+		Sources:   rec.Sources,
+		Resources: rec.Resources,
+	}
+	if err := store.Put(ctx, next); err != nil {
+		a.Log.Errorf("Could not record deployment: %v", err)
+	}
+	return 0
+}
@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/func/func/cloudformation"
+	"github.com/func/func/resource"
+	"github.com/func/func/source"
+)
+
+// ImportOpts contains options for importing existing resources into a stack.
+type ImportOpts struct {
+	DeploymentOpts
+
+	// Resources maps resource names to the physical ids of the existing
+	// resources to import.
+	Resources map[string]string
+}
+
+// Import imports existing resources into the stack. The resources must be
+// defined in the configuration and may not be managed by the stack yet.
+//
+// CloudFormation requires imported resources to have a deletion policy. The
+// Retain policy is only set for the import; the next deployment applies the
+// template generated from the configuration, which does not retain them.
+//
+// Stacks with templates that contain sections func does not generate, such as
+// Parameters or Conditions, cannot be imported into.
+func (a *App) Import(ctx context.Context, dir string, opts ImportOpts) int {
+	defer func() {
+		// Better way to ensure render completes
+		time.Sleep(200 * time.Millisecond)
+	}()
+
+	step := a.Log.Step("Load resource configurations")
+	proj, dir, ok := a.loadProject(dir, opts.Env, step)
+	if !ok {
+		return 1
+	}
+	if proj != nil {
+		if len(proj.Targets) > 0 {
+			step.Errorf("Import is not supported for projects with deployment targets")
+			return 2
+		}
+		opts.setDefaults(proj)
+	}
+	if opts.StackName == "" {
+		step.Errorf("Stack name not set")
+		return 2
+	}
+	resources, diags := a.loadResources(dir, proj)
+	step.PrintDiags(diags, a.loader.Files())
+	if diags.HasErrors() {
+		return 1
+	}
+
+	names := make([]string, 0, len(opts.Resources))
+	for name := range opts.Resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var imported resource.List
+	for _, name := range names {
+		res := resources.ByName(name)
+		if res == nil {
+			step.Errorf("Resource %q is not defined", name)
+			return 2
+		}
+		imported = append(imported, res)
+	}
+	step.Done()
+
+	srcs, err := sources(resources)
+	if err != nil {
+		a.Log.Errorf("Could not collect source files: %v", err)
+		return 1
+	}
+	var importedSrcs []sourcecode
+	for _, src := range srcs {
+		if _, ok := opts.Resources[src.Resource.Name]; ok {
+			importedSrcs = append(importedSrcs, src)
+		}
+	}
+	if len(importedSrcs) > 0 && opts.SourceBucket == "" {
+		a.Log.Errorf("Source bucket not set")
+		return 2
+	}
+
+	cfg, err := opts.AWS.config()
+	if err != nil {
+		a.Log.Errorf("Could not load aws config: %v", err)
+		return 1
+	}
+	cf := cloudformation.NewClient(cfg)
+	s3 := opts.AWS.s3(cfg, opts.SourceBucket)
+	cache := &source.Cache{Dir: cacheDir()}
+
+	step = a.Log.Step("Prepare import")
+	tmpl, diags := cloudformation.Generate(resources, sourceLocations(srcs, s3))
+	step.PrintDiags(diags, a.loader.Files())
+	if diags.HasErrors() {
+		return 1
+	}
+
+	stack, err := cf.StackByName(ctx, opts.StackName)
+	if err != nil {
+		step.Errorf("Could not get stack: %v", err)
+		return 1
+	}
+
+	// The template may only contain the resources currently in the stack and
+	// the imported resources.
+	current := &cloudformation.Template{
+		AWSTemplateFormatVersion: "2010-09-09",
+		Resources:                make(map[string]cloudformation.Resource),
+	}
+	if stack.ID != "" {
+		current, err = cf.Template(ctx, stack)
+		if err != nil {
+			step.Errorf("Could not get current template: %v", err)
+			return 1
+		}
+	}
+	for _, res := range imported {
+		logicalID := cloudformation.LogicalName(res.Name)
+		if _, exists := current.Resources[logicalID]; exists {
+			step.Errorf("Resource %q is already managed by the stack", res.Name)
+			return 2
+		}
+		r := tmpl.Resources[logicalID]
+		r.DeletionPolicy = "Retain"
+		current.Resources[logicalID] = r
+	}
+
+	identifiers, err := cf.ResourceIdentifiers(ctx, current)
+	if err != nil {
+		step.Errorf("Could not get resource identifiers: %v", err)
+		return 1
+	}
+	imports := make([]cloudformation.ImportResource, len(imported))
+	for i, res := range imported {
+		logicalID := cloudformation.LogicalName(res.Name)
+		typ := current.Resources[logicalID].Type
+		id, err := parseImportID(opts.Resources[res.Name], identifiers[typ])
+		if err != nil {
+			step.Errorf("Invalid id for %s: %v", res.Name, err)
+			return 2
+		}
+		imports[i] = cloudformation.ImportResource{
+			LogicalID:  logicalID,
+			Type:       typ,
+			Identifier: id,
+		}
+	}
+	step.Done()
+
+	if len(importedSrcs) > 0 {
+		srcStep := a.Log.Step("Process source code")
+		for _, src := range importedSrcs {
+			s := srcStep.Step(src.Resource.Name)
+			if err := ensureSource(ctx, src, cache, s3, s); err != nil {
+				s.Errorf("Error: %v", err)
+				return 1
+			}
+			s.Done()
+		}
+		srcStep.Done()
+	}
+
+	csOpts := append(opts.changeSetOpts(), cloudformation.WithImport(imports...))
+	changeset, err := cf.CreateChangeSet(ctx, stack, current, csOpts...)
+	if err != nil {
+		a.Log.Errorf("Could not create change set: %v", err)
+		return 1
+	}
+
+	code, _ := a.apply(ctx, a.Log, cf, changeset, tmpl.LookupResource, opts.DeploymentOpts)
+	return code
+}
+
+// parseImportID parses the physical id of a resource to import into its
+// identifier properties.
+//
+// If the resource type has a single identifier property, the id is the value
+// of it. Otherwise the id is a comma separated list of key=value pairs for
+// each identifier.
+func parseImportID(id string, identifiers []string) (map[string]string, error) {
+	if len(identifiers) == 0 {
+		return nil, fmt.Errorf("resource type does not support import")
+	}
+	if !strings.Contains(id, "=") {
+		if len(identifiers) > 1 {
+			return nil, fmt.Errorf("multiple identifiers required, set as %s", strings.Join(identifiers, "=...,")+"=...")
+		}
+		return map[string]string{identifiers[0]: id}, nil
+	}
+	out := make(map[string]string, len(identifiers))
+	for _, kv := range strings.Split(id, ",") {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%q is not a key=value pair", kv)
+		}
+		out[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	for _, ident := range identifiers {
+		if _, ok := out[ident]; !ok {
+			return nil, fmt.Errorf("identifier %s not set", ident)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/func/func/cloudformation"
+	"github.com/func/func/scaffold"
+)
+
+// InitOpts contains options for creating a new project.
+type InitOpts struct {
+	// Template is the name of the template to use.
+	Template string
+
+	// Name is the name of the project. Defaults to the name of the directory.
+	Name string
+
+	// API adds an HTTP API that invokes the function.
+	API bool
+}
+
+// Init creates a new project in the given directory from a template.
+func (a *App) Init(dir string, opts InitOpts) int {
+	defer func() {
+		// Better way to ensure render completes
+		time.Sleep(100 * time.Millisecond)
+	}()
+
+	if opts.Name == "" {
+		opts.Name = filepath.Base(dir)
+	}
+	files, err := scaffold.Write(dir, opts.Template, scaffold.Options{
+		Name: opts.Name,
+		API:  opts.API,
+	})
+	if err != nil {
+		a.Log.Errorf("Could not create project: %v", err)
+		return 1
+	}
+	for _, f := range files {
+		fmt.Fprintf(a.Stdout, "Created %s\n", f)
+	}
+	return 0
+}
+
+// ValidateOpts contains options for validating resource configurations.
+type ValidateOpts struct {
+	Env string
+}
+
+// Validate validates the resource configurations in the project. No requests
+// are made to AWS and no build tools or containers are run.
+func (a *App) Validate(dir string, opts ValidateOpts) int {
+	defer func() {
+		// Better way to ensure render completes
+		time.Sleep(100 * time.Millisecond)
+	}()
+
+	step := a.Log.Step("Validate resource configurations")
+	proj, dir, ok := a.loadProject(dir, opts.Env, step)
+	if !ok {
+		return 1
+	}
+	resources, diags := a.loadResources(dir, proj)
+	step.PrintDiags(diags, a.loader.Files())
+	if diags.HasErrors() {
+		return 1
+	}
+	srcs, err := sources(resources)
+	if err != nil {
+		step.Errorf("Could not collect source files: %v", err)
+		return 1
+	}
+	_, diags = cloudformation.Generate(resources, sourceLocations(srcs, referenceStore("")))
+	step.PrintDiags(diags, a.loader.Files())
+	if diags.HasErrors() {
+		return 1
+	}
+	step.Verbosef("%d resources", len(resources))
+	step.Done()
+	return 0
+}
@@ -167,6 +167,13 @@ func (s *logStep) Done() {
 	s.mu.Unlock()
 }
 
+// Fail marks the step as failed without adding an error message.
+func (s *logStep) Fail() {
+	s.mu.Lock()
+	s.err = true
+	s.mu.Unlock()
+}
+
 func (s *logStep) Step(name string) *logStep {
 	sub := newStep(name, s.Verbose)
 	sub.Prefix = "  "
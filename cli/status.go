@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"context"
+	"time"
+
+	"github.com/func/func/cloudformation"
+	"github.com/func/func/project"
+	"github.com/func/func/ui"
+)
+
+// StatusOpts contains options for inspecting a deployed stack.
+type StatusOpts struct {
+	StackName string
+	Env       string
+	AWS       AWSOpts
+}
+
+func (opts *StatusOpts) setDefaults(p *project.Project) {
+	if opts.StackName == "" {
+		opts.StackName = p.Stack
+	}
+	opts.AWS.setDefaults(p)
+}
+
+// stackStatus loads the project and the current status of the stack. The
+// returned function maps logical ids to resource names.
+func (a *App) stackStatus(ctx context.Context, dir string, opts StatusOpts) (*cloudformation.Client, *cloudformation.StackStatus, func(string) string, int) {
+	step := a.Log.Step("Load project")
+	proj, dir, ok := a.loadProject(dir, opts.Env, step)
+	if !ok {
+		return nil, nil, nil, 1
+	}
+	if proj != nil {
+		opts.setDefaults(proj)
+	}
+	if opts.StackName == "" {
+		step.Errorf("Stack name not set")
+		return nil, nil, nil, 2
+	}
+
+	// Resources are only used for displaying names. The stack may have been
+	// deployed from a different version of the configuration, so errors are
+	// not fatal.
+	names := make(map[string]string)
+	if resources, diags := a.loadResources(dir, proj); !diags.HasErrors() {
+		for _, res := range resources {
+			names[cloudformation.LogicalName(res.Name)] = res.Name
+		}
+	}
+	step.Done()
+
+	cfg, err := opts.AWS.config()
+	if err != nil {
+		a.Log.Errorf("Could not load aws config: %v", err)
+		return nil, nil, nil, 1
+	}
+	cf := cloudformation.NewClient(cfg)
+
+	stack, err := cf.StackByName(ctx, opts.StackName)
+	if err != nil {
+		a.Log.Errorf("Could not get stack: %v", err)
+		return nil, nil, nil, 1
+	}
+	if stack == nil || stack.ID == "" {
+		a.Log.Errorf("Stack %s does not exist", opts.StackName)
+		return nil, nil, nil, 1
+	}
+	status, err := cf.StackStatus(ctx, stack)
+	if err != nil {
+		a.Log.Errorf("Could not get stack status: %v", err)
+		return nil, nil, nil, 1
+	}
+
+	a.Log.Infof("Stack:   %s", stack.Name)
+	a.Log.Infof("Status:  %s", status.Status)
+	if status.Reason != "" {
+		a.Log.Infof("Reason:  %s", status.Reason)
+	}
+	a.Log.Infof("Updated: %s\n", status.Updated.Local().Format(time.RFC1123))
+
+	lookup := func(logicalID string) string { return names[logicalID] }
+	return cf, status, lookup, 0
+}
+
+// Status prints the current status of the stack and the events of the latest
+// deployment.
+func (a *App) Status(ctx context.Context, dir string, opts StatusOpts) int {
+	defer func() {
+		// Better way to ensure render completes
+		time.Sleep(200 * time.Millisecond)
+	}()
+
+	cf, status, names, code := a.stackStatus(ctx, dir, opts)
+	if code != 0 {
+		return code
+	}
+	if status.Deployment == nil {
+		return 0
+	}
+
+	events, err := cf.DeploymentEvents(ctx, status.Deployment)
+	if err != nil {
+		a.Log.Errorf("Could not get events: %v", err)
+		return 1
+	}
+
+	step := a.Log.Step("Deployment " + status.Deployment.ChangeSet.Name)
+	w := &deploymentWatcher{
+		cf:         cf,
+		deployment: status.Deployment,
+		step:       step,
+		names:      names,
+	}
+	for _, ev := range events {
+		w.handle(ev)
+	}
+	switch status.State {
+	case cloudformation.StateInProgress:
+		step.Infof(ui.Format("In progress, use watch to follow", ui.Dim))
+	case cloudformation.StateFailed:
+		step.Fail()
+	case cloudformation.StateComplete:
+		if status.Operation == cloudformation.StackRollback {
+			step.Fail()
+			break
+		}
+		step.Done()
+	}
+	return 0
+}
+
+// Watch attaches to an ongoing deployment and renders its events until it
+// completes. Returns immediately if no deployment is in progress.
+func (a *App) Watch(ctx context.Context, dir string, opts StatusOpts) int {
+	defer func() {
+		// Better way to ensure render completes
+		time.Sleep(200 * time.Millisecond)
+	}()
+
+	cf, status, names, code := a.stackStatus(ctx, dir, opts)
+	if code != 0 {
+		return code
+	}
+	if status.State != cloudformation.StateInProgress || status.Deployment == nil {
+		a.Log.Infof(ui.Format("No deployment in progress", ui.Dim))
+		return 0
+	}
+
+	step := a.Log.Step("Deployment " + status.Deployment.ChangeSet.Name)
+	w := &deploymentWatcher{
+		cf:         cf,
+		deployment: status.Deployment,
+		step:       step,
+		names:      names,
+	}
+	if code := w.watch(ctx); code != 0 {
+		if ctx.Err() != nil {
+			// Detaching does not affect the deployment
+			step.Errorf("Interrupted, deployment continues in the background")
+		}
+		return code
+	}
+	step.Done()
+	return 0
+}
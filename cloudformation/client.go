@@ -3,6 +3,7 @@ package cloudformation
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -21,6 +22,7 @@ type Client struct {
 	// Poller durations
 	changeSetWaitTime time.Duration // Wait for changeset to be ready
 	pollEvents        time.Duration // Read events for deployment
+	pollDrift         time.Duration // Wait for drift detection to complete
 }
 
 // NewClient creates a new CloudFormation client.
@@ -29,6 +31,7 @@ func NewClient(config aws.Config) *Client {
 		api:               cloudformation.New(config),
 		changeSetWaitTime: 250 * time.Millisecond,
 		pollEvents:        500 * time.Millisecond,
+		pollDrift:         time.Second,
 	}
 }
 
@@ -71,9 +74,68 @@ func WithDescription(desc string) ChangeSetOpt {
 	}
 }
 
+// WithTags sets tags on the stack. CloudFormation propagates the tags to all
+// resources in the stack that support tagging.
+func WithTags(tags map[string]string) ChangeSetOpt {
+	return func(input *cloudformation.CreateChangeSetInput) {
+		keys := make([]string, 0, len(tags))
+		for k := range tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		input.Tags = make([]cloudformation.Tag, len(keys))
+		for i, k := range keys {
+			input.Tags[i] = cloudformation.Tag{
+				Key:   aws.String(k),
+				Value: aws.String(tags[k]),
+			}
+		}
+	}
+}
+
+// WithRoleARN sets the service role CloudFormation assumes when creating the
+// change set and executing it. If not set, CloudFormation uses the
+// credentials of the caller.
+func WithRoleARN(arn string) ChangeSetOpt {
+	return func(input *cloudformation.CreateChangeSetInput) {
+		input.RoleARN = aws.String(arn)
+	}
+}
+
+// WithNotificationARNs sets the SNS topics that CloudFormation sends stack
+// events to.
+func WithNotificationARNs(arns ...string) ChangeSetOpt {
+	return func(input *cloudformation.CreateChangeSetInput) {
+		input.NotificationARNs = arns
+	}
+}
+
+// WithRollbackTriggers sets CloudWatch alarms to monitor during the
+// deployment. If any of the alarms go to ALARM state during the deployment,
+// or within the monitoring time after it, the stack is rolled back.
+//
+// CloudFormation monitors in whole minutes, the monitoring time is rounded up
+// to the next minute.
+func WithRollbackTriggers(monitoringTime time.Duration, alarmARNs ...string) ChangeSetOpt {
+	return func(input *cloudformation.CreateChangeSetInput) {
+		triggers := make([]cloudformation.RollbackTrigger, len(alarmARNs))
+		for i, arn := range alarmARNs {
+			triggers[i] = cloudformation.RollbackTrigger{
+				Arn:  aws.String(arn),
+				Type: aws.String("AWS::CloudWatch::Alarm"),
+			}
+		}
+		input.RollbackConfiguration = &cloudformation.RollbackConfiguration{
+			MonitoringTimeInMinutes: aws.Int64(int64((monitoringTime + time.Minute - 1) / time.Minute)),
+			RollbackTriggers:        triggers,
+		}
+	}
+}
+
 // CreateChangeSet creates a new CloudFormation change set.
 //
-// Blocks until the change set has been created in CloudFormation.
+// Blocks until the change set has been created in CloudFormation. If the
+// context is cancelled while waiting, the change set is deleted.
 func (c *Client) CreateChangeSet(ctx context.Context, stack *Stack, template *Template, opts ...ChangeSetOpt) (*ChangeSet, error) {
 	body, err := json.Marshal(template)
 	if err != nil {
@@ -95,7 +157,7 @@ func (c *Client) CreateChangeSet(ctx context.Context, stack *Stack, template *Te
 		opt(input)
 	}
 
-	if stack.ID == "" {
+	if stack.ID == "" && input.ChangeSetType == cloudformation.ChangeSetTypeUpdate {
 		input.ChangeSetType = cloudformation.ChangeSetTypeCreate
 	}
 
@@ -118,6 +180,11 @@ func (c *Client) CreateChangeSet(ctx context.Context, stack *Stack, template *Te
 	}
 
 	if err := cs.loadChanges(ctx, c.api, c.changeSetWaitTime); err != nil {
+		if ctx.Err() != nil {
+			// Do not leave the change set behind. Uses a new context as the
+			// original one has been cancelled.
+			_ = c.DeleteChangeSet(context.Background(), cs)
+		}
 		return nil, fmt.Errorf("collect changes: %w", err)
 	}
 
@@ -154,6 +221,149 @@ func (c *Client) ExecuteChangeSet(ctx context.Context, changeSet *ChangeSet) (*D
 	}, nil
 }
 
+// ErrCreateInProgress is returned by CancelDeployment if the stack is being
+// created. CloudFormation only supports cancelling updates.
+var ErrCreateInProgress = errors.New("stack is being created, creation cannot be cancelled")
+
+// CancelDeployment cancels an ongoing deployment. The stack is rolled back to
+// the previous configuration; the rollback events are included in Events() for
+// the deployment.
+//
+// Only updates to existing stacks can be cancelled. If the stack is being
+// created, ErrCreateInProgress is returned and the creation continues.
+func (c *Client) CancelDeployment(ctx context.Context, deployment *Deployment) error {
+	resp, err := c.api.DescribeStacksRequest(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(deployment.ChangeSet.Stack.Name),
+	}).Send(ctx)
+	if err != nil {
+		return fmt.Errorf("describe stacks: %w", err)
+	}
+	if len(resp.Stacks) > 0 && resp.Stacks[0].StackStatus == cloudformation.StackStatusCreateInProgress {
+		return ErrCreateInProgress
+	}
+	if _, err := c.api.CancelUpdateStackRequest(&cloudformation.CancelUpdateStackInput{
+		StackName:          aws.String(deployment.ChangeSet.Stack.Name),
+		ClientRequestToken: aws.String(deployment.ChangeSet.Name),
+	}).Send(ctx); err != nil {
+		return fmt.Errorf("cancel update: %w", err)
+	}
+	return nil
+}
+
+// SetTerminationProtection enables or disables termination protection on a
+// stack. The stack must exist.
+func (c *Client) SetTerminationProtection(ctx context.Context, stack *Stack, enabled bool) error {
+	if _, err := c.api.UpdateTerminationProtectionRequest(&cloudformation.UpdateTerminationProtectionInput{
+		EnableTerminationProtection: aws.Bool(enabled),
+		StackName:                   aws.String(stack.Name),
+	}).Send(ctx); err != nil {
+		return fmt.Errorf("update termination protection: %w", err)
+	}
+	return nil
+}
+
+// StackStatus returns the current status of a stack. The stack must exist.
+func (c *Client) StackStatus(ctx context.Context, stack *Stack) (*StackStatus, error) {
+	resp, err := c.api.DescribeStacksRequest(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(stack.ID),
+	}).Send(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("describe stacks: %w", err)
+	}
+	if len(resp.Stacks) == 0 {
+		return nil, fmt.Errorf("stack %s not found", stack.Name)
+	}
+	s := resp.Stacks[0]
+	out := &StackStatus{
+		Status:    string(s.StackStatus),
+		Operation: parseStackOp(s.StackStatus),
+		State:     parseState(cloudformation.ResourceStatus(s.StackStatus)),
+	}
+	if s.StackStatusReason != nil {
+		out.Reason = *s.StackStatusReason
+	}
+	switch {
+	case s.LastUpdatedTime != nil:
+		out.Updated = *s.LastUpdatedTime
+	case s.CreationTime != nil:
+		out.Updated = *s.CreationTime
+	}
+
+	events, err := c.api.DescribeStackEventsRequest(&cloudformation.DescribeStackEventsInput{
+		StackName: aws.String(stack.ID),
+	}).Send(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("describe stack events: %w", err)
+	}
+	// Events are returned in reverse chronological order
+	for _, ev := range events.StackEvents {
+		if ev.ClientRequestToken != nil && *ev.ClientRequestToken != "" {
+			out.Deployment = &Deployment{
+				ChangeSet: &ChangeSet{Name: *ev.ClientRequestToken, Stack: stack},
+			}
+			break
+		}
+	}
+
+	return out, nil
+}
+
+// StackOutputs returns the outputs of a stack, keyed by output name. The stack
+// must exist.
+func (c *Client) StackOutputs(ctx context.Context, stack *Stack) (map[string]string, error) {
+	resp, err := c.api.DescribeStacksRequest(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(stack.ID),
+	}).Send(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("describe stacks: %w", err)
+	}
+	if len(resp.Stacks) == 0 {
+		return nil, fmt.Errorf("stack %s not found", stack.Name)
+	}
+	out := make(map[string]string, len(resp.Stacks[0].Outputs))
+	for _, o := range resp.Stacks[0].Outputs {
+		if o.OutputKey == nil || o.OutputValue == nil {
+			continue
+		}
+		out[*o.OutputKey] = *o.OutputValue
+	}
+	return out, nil
+}
+
+// DeploymentEvents returns the events that have occurred in a deployment so
+// far, in chronological order. Unlike Events(), it does not wait for the
+// deployment to complete.
+func (c *Client) DeploymentEvents(ctx context.Context, deployment *Deployment) ([]Event, error) {
+	raw, err := c.deploymentEvents(ctx, deployment)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(raw, func(i, j int) bool {
+		return raw[i].Timestamp.Before(*raw[j].Timestamp)
+	})
+	out := make([]Event, len(raw))
+	for i, ev := range raw {
+		out[i] = convertEvent(ev)
+	}
+	return out, nil
+}
+
+func (c *Client) deploymentEvents(ctx context.Context, deployment *Deployment) ([]cloudformation.StackEvent, error) {
+	resp, err := c.api.DescribeStackEventsRequest(&cloudformation.DescribeStackEventsInput{
+		StackName: aws.String(deployment.ChangeSet.Stack.Name),
+	}).Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var out []cloudformation.StackEvent
+	for _, ev := range resp.StackEvents {
+		if ev.ClientRequestToken != nil && *ev.ClientRequestToken == deployment.ChangeSet.Name {
+			out = append(out, ev)
+		}
+	}
+	return out, nil
+}
+
 // Events watches all events occurring in a deployment. The returned channel is
 // closed when the deployment has completed.
 func (c *Client) Events(ctx context.Context, deployment *Deployment) <-chan Event {
@@ -172,19 +382,11 @@ func (c *Client) Events(ctx context.Context, deployment *Deployment) <-chan Even
 			default:
 			}
 
-			var raw []cloudformation.StackEvent
-			resp, err := c.api.DescribeStackEventsRequest(&cloudformation.DescribeStackEventsInput{
-				StackName: aws.String(deployment.ChangeSet.Stack.Name),
-			}).Send(ctx)
+			raw, err := c.deploymentEvents(ctx, deployment)
 			if err != nil {
 				events <- ErrorEvent{Error: err}
 				return
 			}
-			for _, ev := range resp.StackEvents {
-				if ev.ClientRequestToken != nil && *ev.ClientRequestToken == deployment.ChangeSet.Name {
-					raw = append(raw, ev)
-				}
-			}
 
 			var list []cloudformation.StackEvent
 			last := since
@@ -210,15 +412,11 @@ func (c *Client) Events(ctx context.Context, deployment *Deployment) <-chan Even
 			})
 
 			for _, ev := range list {
-				if *ev.ResourceType == "AWS::CloudFormation::Stack" {
-					out := stackEvent(ev)
-					events <- out
-					if out.State == StateComplete {
-						return
-					}
-					continue
+				out := convertEvent(ev)
+				events <- out
+				if se, ok := out.(StackEvent); ok && se.State == StateComplete {
+					return
 				}
-				events <- resourceEvent(ev)
 			}
 		}
 	}()
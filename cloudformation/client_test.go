@@ -2,6 +2,7 @@ package cloudformation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -189,6 +190,49 @@ func TestClient_CreateChangeSet(t *testing.T) {
 				Description: "test description",
 			},
 		},
+		{
+			name:     "StackOptions",
+			stack:    &Stack{Name: "teststack"},
+			template: &Template{},
+			opts: []ChangeSetOpt{
+				WithTags(map[string]string{"team": "foo", "env": "prod"}),
+				WithRoleARN("arn:aws:iam::123456789012:role/deploy"),
+				WithNotificationARNs("arn:aws:sns:us-east-1:123456789012:topic"),
+				WithRollbackTriggers(5*time.Minute, "arn:aws:cloudwatch:us-east-1:123456789012:alarm:errors"),
+			},
+			createChangeSet: func(input *cloudformation.CreateChangeSetInput) (*cloudformation.CreateChangeSetOutput, error) {
+				want := &cloudformation.CreateChangeSetInput{
+					Tags: []cloudformation.Tag{
+						{Key: aws.String("env"), Value: aws.String("prod")},
+						{Key: aws.String("team"), Value: aws.String("foo")},
+					},
+					RoleARN:          aws.String("arn:aws:iam::123456789012:role/deploy"),
+					NotificationARNs: []string{"arn:aws:sns:us-east-1:123456789012:topic"},
+					RollbackConfiguration: &cloudformation.RollbackConfiguration{
+						MonitoringTimeInMinutes: aws.Int64(5),
+						RollbackTriggers: []cloudformation.RollbackTrigger{{
+							Arn:  aws.String("arn:aws:cloudwatch:us-east-1:123456789012:alarm:errors"),
+							Type: aws.String("AWS::CloudWatch::Alarm"),
+						}},
+					},
+				}
+				got := &cloudformation.CreateChangeSetInput{
+					Tags:                  input.Tags,
+					RoleARN:               input.RoleARN,
+					NotificationARNs:      input.NotificationARNs,
+					RollbackConfiguration: input.RollbackConfiguration,
+				}
+				if diff := cmp.Diff(got, want); diff != "" {
+					return nil, fmt.Errorf("input does not match (-got +want)\n%s", diff)
+				}
+				return &cloudformation.CreateChangeSetOutput{Id: aws.String("id"), StackId: input.StackName}, nil
+			},
+			describeChangeSet: noChanges,
+			want: &ChangeSet{
+				ID:   "id",
+				Name: "func-00000000-000000",
+			},
+		},
 		{
 			name:     "Error",
 			stack:    &Stack{Name: "teststack"},
@@ -232,6 +276,29 @@ func TestClient_CreateChangeSet(t *testing.T) {
 	}
 }
 
+func TestWithRollbackTriggers(t *testing.T) {
+	tests := []struct {
+		name           string
+		monitoringTime time.Duration
+		want           int64
+	}{
+		{name: "Zero", monitoringTime: 0, want: 0},
+		{name: "WholeMinutes", monitoringTime: 5 * time.Minute, want: 5},
+		{name: "PartialMinute", monitoringTime: 90 * time.Second, want: 2},
+		{name: "LessThanMinute", monitoringTime: 30 * time.Second, want: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			input := &cloudformation.CreateChangeSetInput{}
+			WithRollbackTriggers(tc.monitoringTime, "arn")(input)
+			got := *input.RollbackConfiguration.MonitoringTimeInMinutes
+			if got != tc.want {
+				t.Errorf("MonitoringTimeInMinutes = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
 func makeChange(action cloudformation.ChangeAction, id string) cloudformation.Change {
 	return cloudformation.Change{
 		ResourceChange: &cloudformation.ResourceChange{
@@ -352,6 +419,149 @@ func TestClient_ExecuteChangeSet(t *testing.T) {
 	}
 }
 
+func TestClient_CreateChangeSet_cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var deleted string
+	cli := &Client{
+		api: &mockCF{
+			CreateChangeSet: func(input *cloudformation.CreateChangeSetInput) (*cloudformation.CreateChangeSetOutput, error) {
+				return &cloudformation.CreateChangeSetOutput{Id: aws.String("test-id"), StackId: input.StackName}, nil
+			},
+			DescribeChangeSet: func(input *cloudformation.DescribeChangeSetInput) (*cloudformation.DescribeChangeSetOutput, error) {
+				cancel()
+				return &cloudformation.DescribeChangeSetOutput{
+					Status: cloudformation.ChangeSetStatusCreatePending,
+				}, nil
+			},
+			DeleteChangeSet: func(input *cloudformation.DeleteChangeSetInput) (*cloudformation.DeleteChangeSetOutput, error) {
+				deleted = *input.ChangeSetName
+				return &cloudformation.DeleteChangeSetOutput{}, nil
+			},
+		},
+	}
+
+	_, err := cli.CreateChangeSet(ctx, &Stack{Name: "teststack"}, &Template{})
+	if err == nil {
+		t.Fatal("Want error")
+	}
+	if deleted != "test-id" {
+		t.Errorf("Deleted change set = %q, want %q", deleted, "test-id")
+	}
+}
+
+func TestClient_CancelDeployment(t *testing.T) {
+	status := func(status cloudformation.StackStatus) DescribeStacksHook {
+		return func(input *cloudformation.DescribeStacksInput) (*cloudformation.DescribeStacksOutput, error) {
+			return &cloudformation.DescribeStacksOutput{
+				Stacks: []cloudformation.Stack{{
+					StackName:   input.StackName,
+					StackStatus: status,
+				}},
+			}, nil
+		}
+	}
+	tests := []struct {
+		name     string
+		describe DescribeStacksHook
+		cancel   CancelUpdateStackHook
+		wantErr  bool
+		errIs    error
+	}{
+		{
+			name:     "Cancel",
+			describe: status(cloudformation.StackStatusUpdateInProgress),
+			cancel: func(input *cloudformation.CancelUpdateStackInput) (*cloudformation.CancelUpdateStackOutput, error) {
+				if *input.ClientRequestToken != "func-123" {
+					return nil, fmt.Errorf("client request token = %q", *input.ClientRequestToken)
+				}
+				return &cloudformation.CancelUpdateStackOutput{}, nil
+			},
+		},
+		{
+			name:     "Error",
+			describe: status(cloudformation.StackStatusUpdateInProgress),
+			cancel: func(input *cloudformation.CancelUpdateStackInput) (*cloudformation.CancelUpdateStackOutput, error) {
+				return nil, awserr.New("ValidationError", "CancelUpdateStack cannot be called from current stack status", nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "Create",
+			describe: status(cloudformation.StackStatusCreateInProgress),
+			cancel: func(input *cloudformation.CancelUpdateStackInput) (*cloudformation.CancelUpdateStackOutput, error) {
+				return nil, fmt.Errorf("update cancelled during create")
+			},
+			wantErr: true,
+			errIs:   ErrCreateInProgress,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := &Client{
+				api: &mockCF{
+					DescribeStacks:    tc.describe,
+					CancelUpdateStack: tc.cancel,
+				},
+			}
+			deployment := &Deployment{
+				ChangeSet: &ChangeSet{Name: "func-123", Stack: &Stack{Name: "foo"}},
+			}
+			err := cli.CancelDeployment(context.Background(), deployment)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Err = %v, want err = %t", err, tc.wantErr)
+			}
+			if tc.errIs != nil && !errors.Is(err, tc.errIs) {
+				t.Errorf("Err = %v, want %v", err, tc.errIs)
+			}
+		})
+	}
+}
+
+func TestClient_SetTerminationProtection(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		update  UpdateTerminationProtectionHook
+		wantErr bool
+	}{
+		{
+			name:    "Enable",
+			enabled: true,
+			update: func(input *cloudformation.UpdateTerminationProtectionInput) (*cloudformation.UpdateTerminationProtectionOutput, error) {
+				if !*input.EnableTerminationProtection {
+					return nil, fmt.Errorf("termination protection not enabled")
+				}
+				return &cloudformation.UpdateTerminationProtectionOutput{}, nil
+			},
+		},
+		{
+			name:    "Error",
+			enabled: true,
+			update: func(input *cloudformation.UpdateTerminationProtectionInput) (*cloudformation.UpdateTerminationProtectionOutput, error) {
+				return nil, awserr.New("TestError", "err", nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := &Client{
+				api: &mockCF{
+					UpdateTerminationProtection: tc.update,
+				},
+			}
+			err := cli.SetTerminationProtection(context.Background(), &Stack{Name: "foo"}, tc.enabled)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Err = %v, want err = %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
 var numRe = regexp.MustCompile(`\d+`)
 
 func stripNumbers(input string) string {
@@ -433,6 +643,115 @@ func TestClient_Events(t *testing.T) {
 	}
 }
 
+func TestClient_StackStatus(t *testing.T) {
+	updated := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	stack := &Stack{ID: "stack-id", Name: "stack-name"}
+	cli := &Client{
+		api: &mockCF{
+			DescribeStacks: func(input *cloudformation.DescribeStacksInput) (*cloudformation.DescribeStacksOutput, error) {
+				return &cloudformation.DescribeStacksOutput{
+					Stacks: []cloudformation.Stack{{
+						StackId:           input.StackName,
+						StackName:         aws.String("stack-name"),
+						StackStatus:       cloudformation.StackStatusUpdateRollbackInProgress,
+						StackStatusReason: aws.String("Resource failed"),
+						CreationTime:      aws.Time(updated.Add(-time.Hour)),
+						LastUpdatedTime:   aws.Time(updated),
+					}},
+				}, nil
+			},
+			DescribeStackEvents: func(input *cloudformation.DescribeStackEventsInput) (*cloudformation.DescribeStackEventsOutput, error) {
+				// Newest first
+				return &cloudformation.DescribeStackEventsOutput{
+					StackEvents: []cloudformation.StackEvent{
+						{ClientRequestToken: aws.String("func-2")},
+						{ClientRequestToken: aws.String("func-1")},
+					},
+				}, nil
+			},
+		},
+	}
+
+	got, err := cli.StackStatus(context.Background(), stack)
+	if err != nil {
+		t.Fatalf("StackStatus() err = %v", err)
+	}
+	want := &StackStatus{
+		Status:    "UPDATE_ROLLBACK_IN_PROGRESS",
+		Operation: StackRollback,
+		State:     StateInProgress,
+		Reason:    "Resource failed",
+		Updated:   updated,
+		Deployment: &Deployment{
+			ChangeSet: &ChangeSet{Name: "func-2", Stack: stack},
+		},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
+
+func TestClient_StackOutputs(t *testing.T) {
+	stack := &Stack{ID: "stack-id", Name: "stack-name"}
+	cli := &Client{
+		api: &mockCF{
+			DescribeStacks: func(input *cloudformation.DescribeStacksInput) (*cloudformation.DescribeStacksOutput, error) {
+				return &cloudformation.DescribeStacksOutput{
+					Stacks: []cloudformation.Stack{{
+						StackId:   input.StackName,
+						StackName: aws.String("stack-name"),
+						Outputs: []cloudformation.Output{
+							{OutputKey: aws.String("SourceBucket"), OutputValue: aws.String("bucket")},
+						},
+					}},
+				}, nil
+			},
+		},
+	}
+
+	got, err := cli.StackOutputs(context.Background(), stack)
+	if err != nil {
+		t.Fatalf("StackOutputs() err = %v", err)
+	}
+	want := map[string]string{"SourceBucket": "bucket"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
+
+func TestClient_DeploymentEvents(t *testing.T) {
+	deploy := &Deployment{
+		ChangeSet: &ChangeSet{Name: "changeset-name", Stack: &Stack{Name: "stack-name"}},
+	}
+	other := &Deployment{
+		ChangeSet: &ChangeSet{Name: "other-changeset-name", Stack: &Stack{Name: "stack-name"}},
+	}
+	first := makeStackEvent(deploy, cloudformation.ResourceStatusUpdateInProgress)
+	second := makeResourceEvent(deploy, "Foo", cloudformation.ResourceStatusUpdateInProgress)
+	second.Timestamp = aws.Time(first.Timestamp.Add(time.Second))
+	cli := &Client{
+		api: &mockCF{
+			DescribeStackEvents: mockEvents{
+				second,
+				makeResourceEvent(other, "Bar", cloudformation.ResourceStatusUpdateComplete),
+				first,
+			}.Paginate(10),
+		},
+	}
+
+	got, err := cli.DeploymentEvents(context.Background(), deploy)
+	if err != nil {
+		t.Fatalf("DeploymentEvents() err = %v", err)
+	}
+	want := []Event{
+		StackEvent{Operation: StackUpdate, State: StateInProgress},
+		ResourceEvent{Operation: ResourceUpdate, LogicalID: "Foo", State: StateInProgress},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
+
 func makeStackEvent(deploy *Deployment, status cloudformation.ResourceStatus) cloudformation.StackEvent {
 	return cloudformation.StackEvent{
 		ClientRequestToken: aws.String(deploy.ChangeSet.Name),
@@ -490,17 +809,31 @@ type DescribeStacksHook func(input *cloudformation.DescribeStacksInput) (*cloudf
 type DescribeChangeSetHook func(input *cloudformation.DescribeChangeSetInput) (*cloudformation.DescribeChangeSetOutput, error)
 type ExecuteChangeSetHook func(input *cloudformation.ExecuteChangeSetInput) (*cloudformation.ExecuteChangeSetOutput, error)
 type DescribeStackEventsHook func(input *cloudformation.DescribeStackEventsInput) (*cloudformation.DescribeStackEventsOutput, error)
+type DetectStackDriftHook func(input *cloudformation.DetectStackDriftInput) (*cloudformation.DetectStackDriftOutput, error)
+type DescribeStackDriftDetectionStatusHook func(input *cloudformation.DescribeStackDriftDetectionStatusInput) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error)
+type DescribeStackResourceDriftsHook func(input *cloudformation.DescribeStackResourceDriftsInput) (*cloudformation.DescribeStackResourceDriftsOutput, error)
+type GetTemplateHook func(input *cloudformation.GetTemplateInput) (*cloudformation.GetTemplateOutput, error)
+type GetTemplateSummaryHook func(input *cloudformation.GetTemplateSummaryInput) (*cloudformation.GetTemplateSummaryOutput, error)
+type CancelUpdateStackHook func(input *cloudformation.CancelUpdateStackInput) (*cloudformation.CancelUpdateStackOutput, error)
+type UpdateTerminationProtectionHook func(input *cloudformation.UpdateTerminationProtectionInput) (*cloudformation.UpdateTerminationProtectionOutput, error)
 
 type mockCF struct {
 	cloudformationiface.ClientAPI
 
 	// Hooks
-	CreateChangeSet     CreateChangeSetHook
-	DeleteChangeSet     DeleteChangeSetHook
-	DescribeStacks      DescribeStacksHook
-	DescribeChangeSet   DescribeChangeSetHook
-	ExecuteChangeSet    ExecuteChangeSetHook
-	DescribeStackEvents DescribeStackEventsHook
+	CreateChangeSet             CreateChangeSetHook
+	DeleteChangeSet             DeleteChangeSetHook
+	DescribeStacks              DescribeStacksHook
+	DescribeChangeSet           DescribeChangeSetHook
+	ExecuteChangeSet            ExecuteChangeSetHook
+	DescribeStackEvents         DescribeStackEventsHook
+	GetTemplate                 GetTemplateHook
+	GetTemplateSummary          GetTemplateSummaryHook
+	CancelUpdateStack           CancelUpdateStackHook
+	DetectStackDrift            DetectStackDriftHook
+	DescribeDriftStatus         DescribeStackDriftDetectionStatusHook
+	DescribeResourceDrifts      DescribeStackResourceDriftsHook
+	UpdateTerminationProtection UpdateTerminationProtectionHook
 }
 
 func (m *mockCF) req() *aws.Request {
@@ -558,3 +891,59 @@ func (m *mockCF) DescribeStackEventsRequest(input *cloudformation.DescribeStackE
 	})
 	return cloudformation.DescribeStackEventsRequest{Request: req, Input: input}
 }
+
+func (m *mockCF) GetTemplateRequest(input *cloudformation.GetTemplateInput) cloudformation.GetTemplateRequest {
+	req := m.req()
+	req.Handlers.Send.PushBack(func(r *aws.Request) {
+		r.Data, r.Error = m.GetTemplate(input)
+	})
+	return cloudformation.GetTemplateRequest{Request: req, Input: input}
+}
+
+func (m *mockCF) GetTemplateSummaryRequest(input *cloudformation.GetTemplateSummaryInput) cloudformation.GetTemplateSummaryRequest {
+	req := m.req()
+	req.Handlers.Send.PushBack(func(r *aws.Request) {
+		r.Data, r.Error = m.GetTemplateSummary(input)
+	})
+	return cloudformation.GetTemplateSummaryRequest{Request: req, Input: input}
+}
+
+func (m *mockCF) CancelUpdateStackRequest(input *cloudformation.CancelUpdateStackInput) cloudformation.CancelUpdateStackRequest {
+	req := m.req()
+	req.Handlers.Send.PushBack(func(r *aws.Request) {
+		r.Data, r.Error = m.CancelUpdateStack(input)
+	})
+	return cloudformation.CancelUpdateStackRequest{Request: req, Input: input}
+}
+
+func (m *mockCF) DetectStackDriftRequest(input *cloudformation.DetectStackDriftInput) cloudformation.DetectStackDriftRequest {
+	req := m.req()
+	req.Handlers.Send.PushBack(func(r *aws.Request) {
+		r.Data, r.Error = m.DetectStackDrift(input)
+	})
+	return cloudformation.DetectStackDriftRequest{Request: req, Input: input}
+}
+
+func (m *mockCF) DescribeStackDriftDetectionStatusRequest(input *cloudformation.DescribeStackDriftDetectionStatusInput) cloudformation.DescribeStackDriftDetectionStatusRequest {
+	req := m.req()
+	req.Handlers.Send.PushBack(func(r *aws.Request) {
+		r.Data, r.Error = m.DescribeDriftStatus(input)
+	})
+	return cloudformation.DescribeStackDriftDetectionStatusRequest{Request: req, Input: input}
+}
+
+func (m *mockCF) DescribeStackResourceDriftsRequest(input *cloudformation.DescribeStackResourceDriftsInput) cloudformation.DescribeStackResourceDriftsRequest {
+	req := m.req()
+	req.Handlers.Send.PushBack(func(r *aws.Request) {
+		r.Data, r.Error = m.DescribeResourceDrifts(input)
+	})
+	return cloudformation.DescribeStackResourceDriftsRequest{Request: req, Input: input}
+}
+
+func (m *mockCF) UpdateTerminationProtectionRequest(input *cloudformation.UpdateTerminationProtectionInput) cloudformation.UpdateTerminationProtectionRequest {
+	req := m.req()
+	req.Handlers.Send.PushBack(func(r *aws.Request) {
+		r.Data, r.Error = m.UpdateTerminationProtection(input)
+	})
+	return cloudformation.UpdateTerminationProtectionRequest{Request: req, Input: input}
+}
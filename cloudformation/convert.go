@@ -0,0 +1,568 @@
+package cloudformation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/func/func/resource"
+	"github.com/ghodss/yaml"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Convert converts a CloudFormation template to resource configurations. The
+// template may be either json or yaml, short form intrinsic functions such as
+// !Ref are supported.
+//
+// Resource types are matched to the types in the registry by their
+// CloudFormation type. The logical ids in the template are used as resource
+// names. Ref, Fn::GetAtt and Fn::Sub are converted to references. Resources,
+// properties and functions that cannot be converted are added as comments.
+func Convert(body []byte, reg *resource.Registry) ([]byte, error) {
+	js, err := yaml.YAMLToJSON(expandShortForm(body))
+	if err != nil {
+		return nil, fmt.Errorf("parse template: %w", err)
+	}
+	var tmpl struct {
+		Resources map[string]struct {
+			Type       string
+			Properties map[string]interface{}
+			DependsOn  interface{}
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(js))
+	dec.UseNumber()
+	if err := dec.Decode(&tmpl); err != nil {
+		return nil, fmt.Errorf("decode template: %w", err)
+	}
+
+	types := make(map[string]string) // CloudFormation type -> resource type
+	for _, typename := range reg.Types() {
+		v, err := reg.New(typename)
+		if err != nil {
+			return nil, err
+		}
+		if sr, ok := v.Interface().(SupportedResource); ok {
+			types[sr.CloudFormationType()] = typename
+		}
+	}
+
+	c := &converter{configs: make(map[string]reflect.Type)}
+	ids := make([]string, 0, len(tmpl.Resources))
+	for id, res := range tmpl.Resources {
+		ids = append(ids, id)
+		if typename, ok := types[res.Type]; ok {
+			v, _ := reg.New(typename)
+			c.configs[id] = reflect.Indirect(v).Type()
+		}
+	}
+	sort.Strings(ids)
+
+	f := hclwrite.NewEmptyFile()
+	root := f.Body()
+	for i, id := range ids {
+		if i > 0 {
+			root.AppendNewline()
+		}
+		res := tmpl.Resources[id]
+		typename, ok := types[res.Type]
+		if !ok {
+			appendComment(root, fmt.Sprintf("Resource %s: %s is not supported", id, res.Type))
+			continue
+		}
+		block := root.AppendNewBlock("resource", []string{id})
+		b := block.Body()
+		b.SetAttributeValue("type", cty.StringVal(typename))
+		v, _ := reg.New(typename)
+		if _, ok := v.Interface().(SourceSetter); ok {
+			appendComment(b, "Source code must be set in a source block")
+		}
+		if res.DependsOn != nil {
+			appendComment(b, fmt.Sprintf("DependsOn is not supported: %s", compactJSON(res.DependsOn)))
+		}
+		c.writeStruct(b, c.configs[id], res.Properties)
+	}
+
+	return hclwrite.Format(f.Bytes()), nil
+}
+
+type converter struct {
+	configs map[string]reflect.Type // logical id -> config type
+}
+
+// writeStruct writes the properties to the body, in the order the fields are
+// defined in the struct.
+func (c *converter) writeStruct(body *hclwrite.Body, t reflect.Type, props map[string]interface{}) {
+	used := make(map[string]bool, len(props))
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// Unexported
+			continue
+		}
+		if _, ok := field.Tag.Lookup("output"); ok {
+			continue
+		}
+		cfname := cloudFormationName(field)
+		val, ok := props[cfname]
+		if !ok {
+			continue
+		}
+		used[cfname] = true
+		name := strings.Split(field.Tag.Get("input"), ",")[0]
+		if name == "" {
+			appendComment(body, fmt.Sprintf("%s is not supported", cfname))
+			continue
+		}
+		c.writeField(body, name, field.Type, val)
+	}
+
+	var unknown []string
+	for k := range props {
+		if !used[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	for _, k := range unknown {
+		appendComment(body, fmt.Sprintf("%s is not supported", k))
+	}
+}
+
+func (c *converter) writeField(body *hclwrite.Body, name string, t reflect.Type, val interface{}) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if _, ok := intrinsic(val); !ok {
+		switch {
+		case t.Kind() == reflect.Struct:
+			m, ok := val.(map[string]interface{})
+			if !ok {
+				appendComment(body, fmt.Sprintf("%s: %s (expected an object)", name, compactJSON(val)))
+				return
+			}
+			c.writeStruct(body.AppendNewBlock(name, nil).Body(), t, m)
+			return
+		case t.Kind() == reflect.Slice && structElem(t) != nil:
+			list, ok := val.([]interface{})
+			if !ok {
+				appendComment(body, fmt.Sprintf("%s: %s (expected a list)", name, compactJSON(val)))
+				return
+			}
+			et := structElem(t)
+			for _, el := range list {
+				m, ok := el.(map[string]interface{})
+				if !ok {
+					appendComment(body, fmt.Sprintf("%s: %s (expected an object)", name, compactJSON(el)))
+					continue
+				}
+				c.writeStruct(body.AppendNewBlock(name, nil).Body(), et, m)
+			}
+			return
+		}
+	}
+	tokens, err := c.value(t, val)
+	if err != nil {
+		appendComment(body, fmt.Sprintf("%s: %s (%v)", name, compactJSON(val), err))
+		return
+	}
+	body.SetAttributeRaw(name, tokens)
+}
+
+// structElem returns the element type of a slice of structs, or nil if the
+// elements are not structs.
+func structElem(t reflect.Type) reflect.Type {
+	et := t.Elem()
+	if et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct {
+		return nil
+	}
+	return et
+}
+
+// value returns the tokens for an attribute value of the given type.
+func (c *converter) value(t reflect.Type, val interface{}) (hclwrite.Tokens, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if fn, ok := intrinsic(val); ok {
+		return c.intrinsic(fn, val.(map[string]interface{})[fn])
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		switch v := val.(type) {
+		case string:
+			return hclwrite.TokensForValue(cty.StringVal(v)), nil
+		case json.Number:
+			return hclwrite.TokensForValue(cty.StringVal(v.String())), nil
+		case bool:
+			return hclwrite.TokensForValue(cty.StringVal(fmt.Sprint(v))), nil
+		default:
+			// Documents, such as policies, are set as json strings.
+			if containsIntrinsic(val) {
+				return nil, fmt.Errorf("intrinsic functions are not supported in documents")
+			}
+			return heredoc(val)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		var s string
+		switch v := val.(type) {
+		case json.Number:
+			s = v.String()
+		case string:
+			s = v
+		default:
+			return nil, fmt.Errorf("expected a number")
+		}
+		n, err := cty.ParseNumberVal(s)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number")
+		}
+		return hclwrite.TokensForValue(n), nil
+	case reflect.Bool:
+		switch v := val.(type) {
+		case bool:
+			return hclwrite.TokensForValue(cty.BoolVal(v)), nil
+		case string:
+			switch strings.ToLower(v) {
+			case "true":
+				return hclwrite.TokensForValue(cty.True), nil
+			case "false":
+				return hclwrite.TokensForValue(cty.False), nil
+			}
+		}
+		return nil, fmt.Errorf("expected a bool")
+	case reflect.Slice, reflect.Array:
+		list, ok := val.([]interface{})
+		if !ok {
+			list = []interface{}{val}
+		}
+		tokens := hclwrite.Tokens{{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")}}
+		for i, el := range list {
+			if i > 0 {
+				tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenComma, Bytes: []byte(",")})
+			}
+			et, err := c.value(t.Elem(), el)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, et...)
+		}
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")})
+		return tokens, nil
+	case reflect.Map:
+		m, err := mapValue(val)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		tokens := hclwrite.Tokens{
+			{Type: hclsyntax.TokenOBrace, Bytes: []byte("{")},
+			{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+		}
+		for _, k := range keys {
+			vt, err := c.value(t.Elem(), m[k])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			if hclsyntax.ValidIdentifier(k) {
+				tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenIdent, Bytes: []byte(k)})
+			} else {
+				tokens = append(tokens, hclwrite.TokensForValue(cty.StringVal(k))...)
+			}
+			tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenEqual, Bytes: []byte("=")})
+			tokens = append(tokens, vt...)
+			tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+		}
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrace, Bytes: []byte("}")})
+		return tokens, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// intrinsic converts an intrinsic function to a reference.
+func (c *converter) intrinsic(fn string, arg interface{}) (hclwrite.Tokens, error) {
+	switch fn {
+	case "Ref":
+		id, _ := arg.(string)
+		trav, err := c.ref(id)
+		if err != nil {
+			return nil, err
+		}
+		return hclwrite.TokensForTraversal(trav), nil
+	case "Fn::GetAtt":
+		trav, err := c.getAtt(arg)
+		if err != nil {
+			return nil, err
+		}
+		return hclwrite.TokensForTraversal(trav), nil
+	case "Fn::Sub":
+		return c.sub(arg)
+	default:
+		return nil, fmt.Errorf("%s is not supported", fn)
+	}
+}
+
+func (c *converter) ref(id string) (hcl.Traversal, error) {
+	if strings.HasPrefix(id, "AWS::") {
+		return nil, fmt.Errorf("pseudo parameter %s is not supported", id)
+	}
+	t, ok := c.configs[id]
+	if !ok {
+		return nil, fmt.Errorf("reference to %s is not supported", id)
+	}
+	output := outputField(t, func(parts []string) bool { return len(parts) > 1 && parts[1] == "ref" })
+	if output == "" {
+		return nil, fmt.Errorf("Ref to %s is not supported", id)
+	}
+	return traversal(id, output), nil
+}
+
+func (c *converter) getAtt(arg interface{}) (hcl.Traversal, error) {
+	var id, attr string
+	switch v := arg.(type) {
+	case string:
+		parts := strings.SplitN(v, ".", 2)
+		if len(parts) == 2 {
+			id, attr = parts[0], parts[1]
+		}
+	case []interface{}:
+		if len(v) == 2 {
+			id, _ = v[0].(string)
+			attr, _ = v[1].(string)
+		}
+	}
+	if id == "" || attr == "" {
+		return nil, fmt.Errorf("invalid Fn::GetAtt")
+	}
+	t, ok := c.configs[id]
+	if !ok {
+		return nil, fmt.Errorf("reference to %s is not supported", id)
+	}
+	output := outputField(t, func(parts []string) bool {
+		return len(parts) > 1 && parts[0] == attr && parts[1] == "att"
+	})
+	if output == "" {
+		return nil, fmt.Errorf("attribute %s of %s is not supported", attr, id)
+	}
+	return traversal(id, output), nil
+}
+
+func (c *converter) sub(arg interface{}) (hclwrite.Tokens, error) {
+	var str string
+	vars := map[string]interface{}{}
+	switch v := arg.(type) {
+	case string:
+		str = v
+	case []interface{}:
+		if len(v) != 2 {
+			return nil, fmt.Errorf("invalid Fn::Sub")
+		}
+		str, _ = v[0].(string)
+		vars, _ = v[1].(map[string]interface{})
+	default:
+		return nil, fmt.Errorf("invalid Fn::Sub")
+	}
+
+	tokens := hclwrite.Tokens{{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)}}
+	lit := func(s string) {
+		if s == "" {
+			return
+		}
+		// Quote and escape the literal, without the surrounding quotes.
+		q := hclwrite.TokensForValue(cty.StringVal(s))
+		tokens = append(tokens, q[1:len(q)-1]...)
+	}
+	for {
+		start := strings.Index(str, "${")
+		if start < 0 {
+			lit(str)
+			break
+		}
+		end := strings.Index(str[start:], "}")
+		if end < 0 {
+			lit(str)
+			break
+		}
+		end += start
+		lit(str[:start])
+		name := str[start+2 : end]
+		str = str[end+1:]
+
+		if strings.HasPrefix(name, "!") {
+			// Literal ${Name}
+			lit("${" + name[1:] + "}")
+			continue
+		}
+
+		var inner hclwrite.Tokens
+		if v, ok := vars[name]; ok {
+			if s, ok := v.(string); ok {
+				lit(s)
+				continue
+			}
+			fn, ok := intrinsic(v)
+			if !ok {
+				return nil, fmt.Errorf("unsupported variable %s", name)
+			}
+			t, err := c.intrinsic(fn, v.(map[string]interface{})[fn])
+			if err != nil {
+				return nil, err
+			}
+			inner = t
+		} else {
+			var trav hcl.Traversal
+			var err error
+			if strings.Contains(name, ".") {
+				trav, err = c.getAtt(name)
+			} else {
+				trav, err = c.ref(name)
+			}
+			if err != nil {
+				return nil, err
+			}
+			inner = hclwrite.TokensForTraversal(trav)
+		}
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenTemplateInterp, Bytes: []byte("${")})
+		tokens = append(tokens, inner...)
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenTemplateSeqEnd, Bytes: []byte("}")})
+	}
+	tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)})
+	return tokens, nil
+}
+
+// cloudFormationName returns the CloudFormation property name of a field.
+func cloudFormationName(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("cloudformation"); ok {
+		return strings.Split(tag, ",")[0]
+	}
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		return strings.Split(tag, ",")[0]
+	}
+	return field.Name
+}
+
+// outputField returns the name of the output field, for which the parts of
+// the cloudformation tag match.
+func outputField(t reflect.Type, match func(parts []string) bool) string {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("cloudformation")
+		if !ok || !match(strings.Split(tag, ",")) {
+			continue
+		}
+		return strings.Split(field.Tag.Get("output"), ",")[0]
+	}
+	return ""
+}
+
+// intrinsic returns the name of the intrinsic function, if the value is one.
+func intrinsic(val interface{}) (string, bool) {
+	m, ok := val.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return "", false
+	}
+	for k := range m {
+		if k == "Ref" || k == "Condition" || strings.HasPrefix(k, "Fn::") {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+func containsIntrinsic(val interface{}) bool {
+	if _, ok := intrinsic(val); ok {
+		return true
+	}
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for _, el := range v {
+			if containsIntrinsic(el) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, el := range v {
+			if containsIntrinsic(el) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mapValue returns the value as a map. In addition to objects, lists of Key
+// Value pairs are accepted, as used for tags.
+func mapValue(val interface{}) (map[string]interface{}, error) {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		return v, nil
+	case []interface{}:
+		out := make(map[string]interface{}, len(v))
+		for _, el := range v {
+			kv, ok := el.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("expected an object")
+			}
+			k, ok := kv["Key"].(string)
+			if !ok {
+				return nil, fmt.Errorf("expected Key to be set")
+			}
+			out[k] = kv["Value"]
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("expected an object")
+}
+
+func traversal(names ...string) hcl.Traversal {
+	trav := hcl.Traversal{hcl.TraverseRoot{Name: names[0]}}
+	for _, n := range names[1:] {
+		trav = append(trav, hcl.TraverseAttr{Name: n})
+	}
+	return trav
+}
+
+// heredoc returns the value as an indented json heredoc.
+func heredoc(val interface{}) (hclwrite.Tokens, error) {
+	js, err := json.MarshalIndent(val, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	s := strings.ReplaceAll(string(js), "${", "$${")
+	s = strings.ReplaceAll(s, "%{", "%%{")
+	return hclwrite.Tokens{
+		{Type: hclsyntax.TokenOHeredoc, Bytes: []byte("<<EOF\n")},
+		{Type: hclsyntax.TokenStringLit, Bytes: []byte(s + "\n")},
+		{Type: hclsyntax.TokenCHeredoc, Bytes: []byte("EOF")},
+	}, nil
+}
+
+func appendComment(body *hclwrite.Body, text string) {
+	body.AppendUnstructuredTokens(hclwrite.Tokens{
+		{Type: hclsyntax.TokenComment, Bytes: []byte("# " + text + "\n")},
+	})
+}
+
+func compactJSON(val interface{}) string {
+	js, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprint(val)
+	}
+	return string(js)
+}
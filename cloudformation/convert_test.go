@@ -0,0 +1,246 @@
+package cloudformation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/func/func/resource"
+	"github.com/google/go-cmp/cmp"
+)
+
+type convertFunction struct {
+	Code struct {
+		S3Key string `cloudformation:"S3Key"`
+	} `cloudformation:"Code"`
+	Name    string            `input:"name" cloudformation:"FunctionName"`
+	Memory  *int              `input:"memory" cloudformation:"MemorySize"`
+	Role    string            `input:"role" cloudformation:"Role"`
+	Layers  []string          `input:"layers" cloudformation:"Layers"`
+	Tags    map[string]string `input:"tags" cloudformation:"Tags"`
+	Tracing *struct {
+		Mode string `input:"mode" cloudformation:"Mode"`
+	} `input:"tracing" cloudformation:"TracingConfig"`
+	ARN string `output:"arn" cloudformation:"Arn,att"`
+}
+
+func (convertFunction) CloudFormationType() string          { return "AWS::Lambda::Function" }
+func (*convertFunction) SetS3SourceCode(bucket, key string) {}
+
+type convertRole struct {
+	Policy string `input:"policy" cloudformation:"PolicyDocument"`
+	Tags   []struct {
+		Key   string `input:"key"`
+		Value string `input:"value"`
+	} `input:"tags" cloudformation:"Tags"`
+	ID  string `output:"id" cloudformation:"Id,ref"`
+	ARN string `output:"arn" cloudformation:"Arn,att"`
+}
+
+func (convertRole) CloudFormationType() string { return "AWS::IAM::Role" }
+
+func TestConvert(t *testing.T) {
+	reg := &resource.Registry{}
+	reg.Add("test:function", reflect.TypeOf(&convertFunction{}))
+	reg.Add("test:role", reflect.TypeOf(&convertRole{}))
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name: "Properties",
+			input: `
+Resources:
+  Func:
+    Type: AWS::Lambda::Function
+    Properties:
+      FunctionName: foo
+      MemorySize: "256"
+      Layers: [a, b]
+      Tags:
+        - Key: team
+          Value: core
+      TracingConfig:
+        Mode: Active
+      Unknown: true
+`,
+			want: `resource "Func" {
+  type = "test:function"
+  # Source code must be set in a source block
+  name   = "foo"
+  memory = 256
+  layers = ["a", "b"]
+  tags = {
+    team = "core"
+  }
+  tracing {
+    mode = "Active"
+  }
+  # Unknown is not supported
+}
+`,
+		},
+		{
+			name: "References",
+			input: `
+Resources:
+  Func:
+    Type: AWS::Lambda::Function
+    Properties:
+      FunctionName: !Sub "${Role}-func-${!Literal}"
+      Role: !GetAtt Role.Arn
+      Layers:
+        - !Ref Role
+        - !GetAtt [Role, Arn]
+        - !Sub
+          - "${Prefix}-${Arn}"
+          - Prefix: pre
+            Arn: !GetAtt Role.Arn
+  Role:
+    Type: AWS::IAM::Role
+`,
+			want: `resource "Func" {
+  type = "test:function"
+  # Source code must be set in a source block
+  name   = "${Role.id}-func-$${Literal}"
+  role   = Role.arn
+  layers = [Role.id, Role.arn, "pre-${Role.arn}"]
+}
+
+resource "Role" {
+  type = "test:role"
+}
+`,
+		},
+		{
+			name: "Unsupported",
+			input: `{
+  "Resources": {
+    "Bucket": {
+      "Type": "AWS::S3::Bucket"
+    },
+    "Func": {
+      "Type": "AWS::Lambda::Function",
+      "DependsOn": ["Bucket"],
+      "Properties": {
+        "Code": {"S3Key": "key"},
+        "FunctionName": {"Fn::Join": ["-", ["a", "b"]]},
+        "Role": {"Ref": "Bucket"},
+        "Layers": [{"Ref": "AWS::Region"}]
+      }
+    }
+  }
+}`,
+			want: `# Resource Bucket: AWS::S3::Bucket is not supported
+
+resource "Func" {
+  type = "test:function"
+  # Source code must be set in a source block
+  # DependsOn is not supported: ["Bucket"]
+  # Code is not supported
+  # name: {"Fn::Join":["-",["a","b"]]} (Fn::Join is not supported)
+  # role: {"Ref":"Bucket"} (reference to Bucket is not supported)
+  # layers: [{"Ref":"AWS::Region"}] (pseudo parameter AWS::Region is not supported)
+}
+`,
+		},
+		{
+			name: "Document",
+			input: `
+Resources:
+  Role:
+    Type: AWS::IAM::Role
+    Properties:
+      PolicyDocument:
+        Statement:
+          - Action: s3:GetObject
+            Resource: arn:aws:s3:::${bucket}/*
+      Tags:
+        - Key: a
+          Value: b
+`,
+			want: `resource "Role" {
+  type   = "test:role"
+  policy = <<EOF
+{
+  "Statement": [
+    {
+      "Action": "s3:GetObject",
+      "Resource": "arn:aws:s3:::$${bucket}/*"
+    }
+  ]
+}
+EOF
+  tags {
+    key   = "a"
+    value = "b"
+  }
+}
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Convert([]byte(tc.input), reg)
+			if err != nil {
+				t.Fatalf("Convert() err = %v", err)
+			}
+			if diff := cmp.Diff(string(got), tc.want); diff != "" {
+				t.Errorf("Convert() (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExpandShortForm(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "Inline",
+			input: "Value: !Ref Foo",
+			want:  `Value: {"Ref": Foo}`,
+		},
+		{
+			name:  "Nested",
+			input: "Value: !Sub [ '${A}', { A: !GetAtt Foo.Arn } ]",
+			want:  `Value: {"Fn::Sub": [ '${A}', { A: {"Fn::GetAtt": Foo.Arn} } ]}`,
+		},
+		{
+			name:  "Quoted",
+			input: "Value: '!Ref Foo' # !Ref Bar",
+			want:  "Value: '!Ref Foo' # !Ref Bar",
+		},
+		{
+			name:  "Sequence",
+			input: "List:\n  - !Ref A\n  - !GetAtt B.Arn",
+			want:  "List:\n  - {\"Ref\": A}\n  - {\"Fn::GetAtt\": B.Arn}",
+		},
+		{
+			name:  "Trailing",
+			input: "Value: !Join\n  - ''\n  - [a, !Ref B]\nOther: c",
+			want:  "Value:\n  Fn::Join:\n    - ''\n    - [a, {\"Ref\": B}]\nOther: c",
+		},
+		{
+			name:  "TrailingSequence",
+			input: "List:\n  - !Sub\n    - '${A}'\n    - A: b",
+			want:  "List:\n  - Fn::Sub:\n      - '${A}'\n      - A: b",
+		},
+		{
+			name:  "BlockScalar",
+			input: "Value: !Sub |\n  line ${A}\nOther: c",
+			want:  "Value:\n  Fn::Sub: |\n    line ${A}\nOther: c",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := string(expandShortForm([]byte(tc.input)))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("expandShortForm() (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package cloudformation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+)
+
+// Drift describes differences between the deployed resources and the
+// resources in the template that was used to deploy them.
+type Drift struct {
+	// Status is the drift status of the stack; DRIFTED, IN_SYNC or
+	// NOT_CHECKED.
+	Status string `json:"status"`
+
+	// Reason is set if drift detection could not complete for all resources.
+	Reason string `json:"reason,omitempty"`
+
+	Resources []ResourceDrift `json:"resources"`
+}
+
+// Drifted returns true if any resource has drifted.
+func (d *Drift) Drifted() bool {
+	return d.Status == string(cloudformation.StackDriftStatusDrifted)
+}
+
+// ResourceDrift describes the drift of a single resource.
+type ResourceDrift struct {
+	LogicalID  string `json:"logical_id"`
+	PhysicalID string `json:"physical_id,omitempty"`
+	Type       string `json:"type"`
+
+	// Status is the drift status of the resource; IN_SYNC, MODIFIED, DELETED
+	// or NOT_CHECKED.
+	Status string `json:"status"`
+
+	Differences []PropertyDifference `json:"differences,omitempty"`
+}
+
+// PropertyDifference describes a property that differs from the expected
+// value.
+type PropertyDifference struct {
+	Path     string `json:"path"`
+	Type     string `json:"type"` // ADD, REMOVE or NOT_EQUAL
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// DetectDrift runs drift detection on a stack. The stack must exist.
+//
+// Blocks until drift detection has completed.
+func (c *Client) DetectDrift(ctx context.Context, stack *Stack) (*Drift, error) {
+	resp, err := c.api.DetectStackDriftRequest(&cloudformation.DetectStackDriftInput{
+		StackName: aws.String(stack.Name),
+	}).Send(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("detect drift: %w", err)
+	}
+
+	out := &Drift{}
+	for {
+		status, err := c.api.DescribeStackDriftDetectionStatusRequest(&cloudformation.DescribeStackDriftDetectionStatusInput{
+			StackDriftDetectionId: resp.StackDriftDetectionId,
+		}).Send(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describe detection status: %w", err)
+		}
+		if status.DetectionStatus == cloudformation.StackDriftDetectionStatusDetectionInProgress {
+			timer := time.NewTimer(c.pollDrift)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+			continue
+		}
+		out.Status = string(status.StackDriftStatus)
+		if status.DetectionStatus == cloudformation.StackDriftDetectionStatusDetectionFailed && status.DetectionStatusReason != nil {
+			// Results are available for resources that could be checked.
+			out.Reason = *status.DetectionStatusReason
+		}
+		break
+	}
+
+	var nextToken *string
+	for {
+		res, err := c.api.DescribeStackResourceDriftsRequest(&cloudformation.DescribeStackResourceDriftsInput{
+			StackName: aws.String(stack.Name),
+			NextToken: nextToken,
+		}).Send(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describe resource drifts: %w", err)
+		}
+		for _, d := range res.StackResourceDrifts {
+			out.Resources = append(out.Resources, resourceDrift(d))
+		}
+		if res.NextToken == nil {
+			return out, nil
+		}
+		nextToken = res.NextToken
+	}
+}
+
+func resourceDrift(d cloudformation.StackResourceDrift) ResourceDrift {
+	out := ResourceDrift{
+		LogicalID: *d.LogicalResourceId,
+		Type:      *d.ResourceType,
+		Status:    string(d.StackResourceDriftStatus),
+	}
+	if d.PhysicalResourceId != nil {
+		out.PhysicalID = *d.PhysicalResourceId
+	}
+	for _, p := range d.PropertyDifferences {
+		out.Differences = append(out.Differences, PropertyDifference{
+			Path:     aws.StringValue(p.PropertyPath),
+			Type:     string(p.DifferenceType),
+			Expected: aws.StringValue(p.ExpectedValue),
+			Actual:   aws.StringValue(p.ActualValue),
+		})
+	}
+	return out
+}
@@ -0,0 +1,162 @@
+package cloudformation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_DetectDrift(t *testing.T) {
+	detect := func(input *cloudformation.DetectStackDriftInput) (*cloudformation.DetectStackDriftOutput, error) {
+		return &cloudformation.DetectStackDriftOutput{StackDriftDetectionId: aws.String("detection-id")}, nil
+	}
+
+	tests := []struct {
+		name    string
+		detect  DetectStackDriftHook
+		status  DescribeStackDriftDetectionStatusHook
+		drifts  DescribeStackResourceDriftsHook
+		want    *Drift
+		wantErr bool
+	}{
+		{
+			name:   "Drifted",
+			detect: detect,
+			status: func() DescribeStackDriftDetectionStatusHook {
+				attempts := 0
+				return func(input *cloudformation.DescribeStackDriftDetectionStatusInput) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error) {
+					attempts++
+					if attempts < 3 {
+						return &cloudformation.DescribeStackDriftDetectionStatusOutput{
+							DetectionStatus: cloudformation.StackDriftDetectionStatusDetectionInProgress,
+						}, nil
+					}
+					return &cloudformation.DescribeStackDriftDetectionStatusOutput{
+						DetectionStatus:  cloudformation.StackDriftDetectionStatusDetectionComplete,
+						StackDriftStatus: cloudformation.StackDriftStatusDrifted,
+					}, nil
+				}
+			}(),
+			drifts: func(input *cloudformation.DescribeStackResourceDriftsInput) (*cloudformation.DescribeStackResourceDriftsOutput, error) {
+				if input.NextToken == nil {
+					return &cloudformation.DescribeStackResourceDriftsOutput{
+						StackResourceDrifts: []cloudformation.StackResourceDrift{{
+							LogicalResourceId:        aws.String("Func"),
+							PhysicalResourceId:       aws.String("func-123"),
+							ResourceType:             aws.String("AWS::Lambda::Function"),
+							StackResourceDriftStatus: cloudformation.StackResourceDriftStatusModified,
+							PropertyDifferences: []cloudformation.PropertyDifference{{
+								PropertyPath:   aws.String("/MemorySize"),
+								DifferenceType: cloudformation.DifferenceTypeNotEqual,
+								ExpectedValue:  aws.String("128"),
+								ActualValue:    aws.String("256"),
+							}},
+						}},
+						NextToken: aws.String("next"),
+					}, nil
+				}
+				return &cloudformation.DescribeStackResourceDriftsOutput{
+					StackResourceDrifts: []cloudformation.StackResourceDrift{{
+						LogicalResourceId:        aws.String("Role"),
+						ResourceType:             aws.String("AWS::IAM::Role"),
+						StackResourceDriftStatus: cloudformation.StackResourceDriftStatusInSync,
+					}},
+				}, nil
+			},
+			want: &Drift{
+				Status: "DRIFTED",
+				Resources: []ResourceDrift{
+					{
+						LogicalID:  "Func",
+						PhysicalID: "func-123",
+						Type:       "AWS::Lambda::Function",
+						Status:     "MODIFIED",
+						Differences: []PropertyDifference{
+							{Path: "/MemorySize", Type: "NOT_EQUAL", Expected: "128", Actual: "256"},
+						},
+					},
+					{
+						LogicalID: "Role",
+						Type:      "AWS::IAM::Role",
+						Status:    "IN_SYNC",
+					},
+				},
+			},
+		},
+		{
+			name:   "PartialFailure",
+			detect: detect,
+			status: func(input *cloudformation.DescribeStackDriftDetectionStatusInput) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error) {
+				return &cloudformation.DescribeStackDriftDetectionStatusOutput{
+					DetectionStatus:       cloudformation.StackDriftDetectionStatusDetectionFailed,
+					DetectionStatusReason: aws.String("Failed to detect drift on resource"),
+					StackDriftStatus:      cloudformation.StackDriftStatusInSync,
+				}, nil
+			},
+			drifts: func(input *cloudformation.DescribeStackResourceDriftsInput) (*cloudformation.DescribeStackResourceDriftsOutput, error) {
+				return &cloudformation.DescribeStackResourceDriftsOutput{}, nil
+			},
+			want: &Drift{
+				Status: "IN_SYNC",
+				Reason: "Failed to detect drift on resource",
+			},
+		},
+		{
+			name: "Error",
+			detect: func(input *cloudformation.DetectStackDriftInput) (*cloudformation.DetectStackDriftOutput, error) {
+				return nil, awserr.New("ValidationError", "Stack does not exist", nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := &Client{
+				api: &mockCF{
+					DetectStackDrift:       tc.detect,
+					DescribeDriftStatus:    tc.status,
+					DescribeResourceDrifts: tc.drifts,
+				},
+			}
+			got, err := cli.DetectDrift(context.Background(), &Stack{Name: "stack"})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Err = %v, want err = %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("Diff (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_DetectDrift_cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cli := &Client{
+		api: &mockCF{
+			DetectStackDrift: func(input *cloudformation.DetectStackDriftInput) (*cloudformation.DetectStackDriftOutput, error) {
+				return &cloudformation.DetectStackDriftOutput{StackDriftDetectionId: aws.String("detection-id")}, nil
+			},
+			DescribeDriftStatus: func(input *cloudformation.DescribeStackDriftDetectionStatusInput) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error) {
+				cancel()
+				return &cloudformation.DescribeStackDriftDetectionStatusOutput{
+					DetectionStatus: cloudformation.StackDriftDetectionStatusDetectionInProgress,
+				}, nil
+			},
+		},
+		// Cancelling must not wait for the next poll.
+		pollDrift: time.Hour,
+	}
+
+	_, err := cli.DetectDrift(ctx, &Stack{Name: "stack"})
+	if err != context.Canceled {
+		t.Errorf("Err = %v, want %v", err, context.Canceled)
+	}
+}
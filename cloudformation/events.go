@@ -44,6 +44,13 @@ type ErrorEvent struct {
 
 func (ErrorEvent) isEvent() {}
 
+func convertEvent(ev cloudformation.StackEvent) Event {
+	if *ev.ResourceType == "AWS::CloudFormation::Stack" {
+		return stackEvent(ev)
+	}
+	return resourceEvent(ev)
+}
+
 func stackEvent(ev cloudformation.StackEvent) StackEvent {
 	reason := ""
 	if ev.ResourceStatusReason != nil {
@@ -16,14 +16,23 @@ type Template struct {
 	AWSTemplateFormatVersion string              `json:"AWSTemplateFormatVersion"`
 	Description              string              `json:"Description,omitempty"`
 	Resources                map[string]Resource `json:"Resources,omitempty"`
+	Outputs                  map[string]Output   `json:"Outputs,omitempty"`
 
 	logicalMapping map[string]string // CloudFormation logical ID -> resource name
 }
 
 // A Resource is a CloudFormation encoded resource.
 type Resource struct {
-	Type       string                 `json:"Type"`
-	Properties map[string]interface{} `json:"Properties,omitempty"`
+	Type           string                 `json:"Type"`
+	DeletionPolicy string                 `json:"DeletionPolicy,omitempty"`
+	Properties     map[string]interface{} `json:"Properties,omitempty"`
+}
+
+// An Output is a value exported by a stack. Outputs can be read from the
+// stack after it has been deployed.
+type Output struct {
+	Description string      `json:"Description,omitempty"`
+	Value       interface{} `json:"Value"`
 }
 
 // SupportedResource is implemented by resource configs that have a
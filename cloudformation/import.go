@@ -0,0 +1,90 @@
+package cloudformation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/ghodss/yaml"
+)
+
+// An ImportResource identifies an existing resource to import into a stack.
+type ImportResource struct {
+	LogicalID string
+	Type      string
+
+	// Identifier contains the identifier properties of the resource, such as
+	// FunctionName for AWS::Lambda::Function.
+	Identifier map[string]string
+}
+
+// WithImport creates a change set that imports existing resources into the
+// stack. The template for the change set must contain the resources already
+// in the stack and the imported resources, but no other changes.
+//
+// Imported resources must have a DeletionPolicy set in the template.
+func WithImport(resources ...ImportResource) ChangeSetOpt {
+	return func(input *cloudformation.CreateChangeSetInput) {
+		input.ChangeSetType = cloudformation.ChangeSetTypeImport
+		input.ResourcesToImport = make([]cloudformation.ResourceToImport, len(resources))
+		for i, r := range resources {
+			input.ResourcesToImport[i] = cloudformation.ResourceToImport{
+				LogicalResourceId:  aws.String(r.LogicalID),
+				ResourceType:       aws.String(r.Type),
+				ResourceIdentifier: r.Identifier,
+			}
+		}
+	}
+}
+
+// Template returns the template the stack was last deployed with. The stack
+// must exist.
+//
+// Only the sections func generates are supported. An error is returned if
+// the template contains other sections, such as Parameters or Conditions, or
+// resource attributes such as DependsOn, as they would be lost when the
+// template is deployed again.
+func (c *Client) Template(ctx context.Context, stack *Stack) (*Template, error) {
+	resp, err := c.api.GetTemplateRequest(&cloudformation.GetTemplateInput{
+		StackName:     aws.String(stack.Name),
+		TemplateStage: cloudformation.TemplateStageOriginal,
+	}).Send(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get template: %w", err)
+	}
+	// Templates not created by func may be in yaml.
+	body, err := yaml.YAMLToJSON([]byte(*resp.TemplateBody))
+	if err != nil {
+		return nil, fmt.Errorf("convert template to json: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	var tmpl Template
+	if err := dec.Decode(&tmpl); err != nil {
+		return nil, fmt.Errorf("decode template: %w", err)
+	}
+	return &tmpl, nil
+}
+
+// ResourceIdentifiers returns the properties that identify resources when
+// importing them, per CloudFormation resource type in the template.
+func (c *Client) ResourceIdentifiers(ctx context.Context, template *Template) (map[string][]string, error) {
+	body, err := json.Marshal(template)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+	resp, err := c.api.GetTemplateSummaryRequest(&cloudformation.GetTemplateSummaryInput{
+		TemplateBody: aws.String(string(body)),
+	}).Send(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get template summary: %w", err)
+	}
+	out := make(map[string][]string, len(resp.ResourceIdentifierSummaries))
+	for _, s := range resp.ResourceIdentifierSummaries {
+		out[*s.ResourceType] = s.ResourceIdentifiers
+	}
+	return out, nil
+}
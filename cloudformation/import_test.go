@@ -0,0 +1,160 @@
+package cloudformation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_Template(t *testing.T) {
+	want := &Template{
+		AWSTemplateFormatVersion: "2010-09-09",
+		Resources: map[string]Resource{
+			"Role": {
+				Type:           "AWS::IAM::Role",
+				DeletionPolicy: "Retain",
+				Properties:     map[string]interface{}{"RoleName": "role"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "JSON",
+			body: `{"AWSTemplateFormatVersion":"2010-09-09","Resources":{"Role":{"Type":"AWS::IAM::Role","DeletionPolicy":"Retain","Properties":{"RoleName":"role"}}}}`,
+		},
+		{
+			name: "YAML",
+			body: "AWSTemplateFormatVersion: '2010-09-09'\n" +
+				"Resources:\n" +
+				"  Role:\n" +
+				"    Type: AWS::IAM::Role\n" +
+				"    DeletionPolicy: Retain\n" +
+				"    Properties:\n" +
+				"      RoleName: role\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := &Client{
+				api: &mockCF{
+					GetTemplate: func(input *cloudformation.GetTemplateInput) (*cloudformation.GetTemplateOutput, error) {
+						return &cloudformation.GetTemplateOutput{TemplateBody: aws.String(tc.body)}, nil
+					},
+				},
+			}
+			got, err := cli.Template(context.Background(), &Stack{Name: "stack"})
+			if err != nil {
+				t.Fatalf("Template() err = %v", err)
+			}
+			if diff := cmp.Diff(got, want, compareTemplate()); diff != "" {
+				t.Errorf("Diff (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_Template_unsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "Parameters",
+			body: `{"AWSTemplateFormatVersion":"2010-09-09","Parameters":{"Name":{"Type":"String"}},"Resources":{}}`,
+		},
+		{
+			name: "Conditions",
+			body: `{"AWSTemplateFormatVersion":"2010-09-09","Conditions":{"Prod":{"Fn::Equals":["a","b"]}},"Resources":{}}`,
+		},
+		{
+			name: "DependsOn",
+			body: `{"AWSTemplateFormatVersion":"2010-09-09","Resources":{"Role":{"Type":"AWS::IAM::Role","DependsOn":["Other"]}}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := &Client{
+				api: &mockCF{
+					GetTemplate: func(input *cloudformation.GetTemplateInput) (*cloudformation.GetTemplateOutput, error) {
+						return &cloudformation.GetTemplateOutput{TemplateBody: aws.String(tc.body)}, nil
+					},
+				},
+			}
+			if _, err := cli.Template(context.Background(), &Stack{Name: "stack"}); err == nil {
+				t.Errorf("Template() did not return an error")
+			}
+		})
+	}
+}
+
+func TestClient_ResourceIdentifiers(t *testing.T) {
+	cli := &Client{
+		api: &mockCF{
+			GetTemplateSummary: func(input *cloudformation.GetTemplateSummaryInput) (*cloudformation.GetTemplateSummaryOutput, error) {
+				return &cloudformation.GetTemplateSummaryOutput{
+					ResourceIdentifierSummaries: []cloudformation.ResourceIdentifierSummary{
+						{ResourceType: aws.String("AWS::Lambda::Function"), ResourceIdentifiers: []string{"FunctionName"}},
+						{ResourceType: aws.String("AWS::ApiGatewayV2::Stage"), ResourceIdentifiers: []string{"ApiId", "StageName"}},
+					},
+				}, nil
+			},
+		},
+	}
+	got, err := cli.ResourceIdentifiers(context.Background(), &Template{})
+	if err != nil {
+		t.Fatalf("ResourceIdentifiers() err = %v", err)
+	}
+	want := map[string][]string{
+		"AWS::Lambda::Function":    {"FunctionName"},
+		"AWS::ApiGatewayV2::Stage": {"ApiId", "StageName"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
+
+func TestWithImport(t *testing.T) {
+	var got *cloudformation.CreateChangeSetInput
+	cli := &Client{
+		api: &mockCF{
+			CreateChangeSet: func(input *cloudformation.CreateChangeSetInput) (*cloudformation.CreateChangeSetOutput, error) {
+				got = input
+				return &cloudformation.CreateChangeSetOutput{Id: aws.String("id")}, nil
+			},
+			DescribeChangeSet: func(input *cloudformation.DescribeChangeSetInput) (*cloudformation.DescribeChangeSetOutput, error) {
+				return &cloudformation.DescribeChangeSetOutput{Status: cloudformation.ChangeSetStatusCreateComplete}, nil
+			},
+		},
+	}
+
+	// Stack does not exist, import creates it
+	_, err := cli.CreateChangeSet(context.Background(), &Stack{Name: "stack"}, &Template{}, WithImport(ImportResource{
+		LogicalID:  "Func",
+		Type:       "AWS::Lambda::Function",
+		Identifier: map[string]string{"FunctionName": "func"},
+	}))
+	if err != nil {
+		t.Fatalf("CreateChangeSet() err = %v", err)
+	}
+
+	if got.ChangeSetType != cloudformation.ChangeSetTypeImport {
+		t.Errorf("ChangeSetType = %q, want %q", got.ChangeSetType, cloudformation.ChangeSetTypeImport)
+	}
+	want := []cloudformation.ResourceToImport{{
+		LogicalResourceId:  aws.String("Func"),
+		ResourceType:       aws.String("AWS::Lambda::Function"),
+		ResourceIdentifier: map[string]string{"FunctionName": "func"},
+	}}
+	if diff := cmp.Diff(got.ResourcesToImport, want, cmp.AllowUnexported(cloudformation.ResourceToImport{})); diff != "" {
+		t.Errorf("ResourcesToImport diff (-got +want)\n%s", diff)
+	}
+}
@@ -34,3 +34,8 @@ func resourceName(input string) string {
 	}
 	return buf.String()
 }
+
+// LogicalName returns the logical name of a resource in a generated template.
+func LogicalName(name string) string {
+	return resourceName(name)
+}
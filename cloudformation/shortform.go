@@ -0,0 +1,213 @@
+package cloudformation
+
+import (
+	"regexp"
+	"strings"
+)
+
+// trailingTag matches a line that ends with a short form intrinsic function
+// tag, where the value of the function is on the following lines:
+//
+//	Value: !Join
+//	  - ''
+//	  - [a, b]
+var trailingTag = regexp.MustCompile(`^(\s*(?:- )*(?:[^\s].*:\s+)?)!(\w+)(\s+[|>][-+0-9]*)?\s*$`)
+
+// expandShortForm rewrites short form intrinsic functions in a yaml
+// CloudFormation template to the full form, so the template can be decoded
+// with a regular yaml decoder. For example, `!Ref Foo` is rewritten to
+// `{"Ref": Foo}`.
+func expandShortForm(src []byte) []byte {
+	lines := strings.Split(string(src), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := stripComment(lines[i])
+		if m := trailingTag.FindStringSubmatch(line); m != nil {
+			prefix, fn, indicator := m[1], functionName(m[2]), strings.TrimSpace(m[3])
+			trimmed := strings.TrimRight(prefix, " ")
+			var keyIndent int
+			var head string
+			if strings.HasSuffix(trimmed, ":") {
+				// Mapping value, nest function under the key
+				keyIndent = len(prefix) - len(strings.TrimLeft(prefix, " -"))
+				head = trimmed
+				lines[i] = expandInline(head)
+				repl := strings.Repeat(" ", keyIndent+2) + fn + ":"
+				if indicator != "" {
+					repl += " " + indicator
+				}
+				lines = append(lines[:i+1], append([]string{repl}, lines[i+1:]...)...)
+				i++
+			} else {
+				// Sequence item or document root
+				keyIndent = len(prefix)
+				lines[i] = prefix + fn + ":"
+				if indicator != "" {
+					lines[i] += " " + indicator
+				}
+				keyIndent -= 2
+			}
+			// Indent the value of the function
+			for j := i + 1; j < len(lines); j++ {
+				l := lines[j]
+				if strings.TrimSpace(l) == "" {
+					continue
+				}
+				if indentation(l) <= keyIndent {
+					break
+				}
+				lines[j] = "  " + l
+			}
+			continue
+		}
+		lines[i] = expandInline(lines[i])
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// expandInline expands short form functions where the value is on the same
+// line.
+func expandInline(line string) string {
+	for {
+		positions := tagPositions(line)
+		if len(positions) == 0 {
+			return line
+		}
+		// Expand from the right, nested functions are expanded first.
+		pos := positions[len(positions)-1]
+		end := pos + 1
+		for end < len(line) && isWordChar(line[end]) {
+			end++
+		}
+		fn := functionName(line[pos+1 : end])
+		start := end
+		for start < len(line) && line[start] == ' ' {
+			start++
+		}
+		valueEnd := scalarEnd(line, start, flowDepth(line[:pos]) > 0)
+		value := strings.TrimRight(line[start:valueEnd], " ")
+		line = line[:pos] + `{"` + fn + `": ` + value + `}` + line[start+len(value):]
+	}
+}
+
+// tagPositions returns the positions of all tags outside of quoted strings
+// and comments.
+func tagPositions(line string) []int {
+	var out []int
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' '):
+			return out
+		case c == '!' && i+1 < len(line) && isWordChar(line[i+1]) && (i == 0 || strings.IndexByte(" [{,", line[i-1]) >= 0):
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// scalarEnd returns the end position of the value starting at start.
+func scalarEnd(line string, start int, inFlow bool) int {
+	if start >= len(line) {
+		return start
+	}
+	switch c := line[start]; c {
+	case '[', '{':
+		depth := 0
+		var quote byte
+		for i := start; i < len(line); i++ {
+			ch := line[i]
+			switch {
+			case quote != 0:
+				if ch == quote {
+					quote = 0
+				}
+			case ch == '"' || ch == '\'':
+				quote = ch
+			case ch == '[' || ch == '{':
+				depth++
+			case ch == ']' || ch == '}':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return len(line)
+	case '"', '\'':
+		if i := strings.IndexByte(line[start+1:], c); i >= 0 {
+			return start + i + 2
+		}
+		return len(line)
+	}
+	for i := start; i < len(line); i++ {
+		if inFlow && strings.IndexByte(",]}", line[i]) >= 0 {
+			return i
+		}
+		if line[i] == '#' && line[i-1] == ' ' {
+			return i
+		}
+	}
+	return len(line)
+}
+
+func flowDepth(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		}
+	}
+	return depth
+}
+
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' '):
+			return strings.TrimRight(line[:i], " ")
+		}
+	}
+	return line
+}
+
+func functionName(tag string) string {
+	switch tag {
+	case "Ref", "Condition":
+		return tag
+	}
+	return "Fn::" + tag
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
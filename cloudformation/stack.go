@@ -1,7 +1,24 @@
 package cloudformation
 
+import "time"
+
 // A Stack represents a CloudFormation stack.
 type Stack struct {
 	ID   string
 	Name string
 }
+
+// A StackStatus describes the current status of a stack.
+type StackStatus struct {
+	// Status is the raw CloudFormation status, such as UPDATE_IN_PROGRESS.
+	Status    string
+	Operation StackOperation
+	State     State
+	Reason    string
+	Updated   time.Time
+
+	// Deployment is the latest operation performed on the stack, identified
+	// by its client request token. Nil if the latest operation was not
+	// performed with a client request token.
+	Deployment *Deployment
+}
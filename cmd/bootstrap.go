@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/func/func/cli"
+	"github.com/spf13/cobra"
+)
+
+func bootstrapCommand(global *globalFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bootstrap",
+		Short: "Create a source bucket for the account and region",
+		Long: "Create a source bucket for the account and region.\n\n" +
+			"The bucket is managed by the func-bootstrap CloudFormation stack. It is " +
+			"encrypted and blocks public access. Deployments use the bucket when no " +
+			"source bucket is set. Running bootstrap again updates the stack.",
+		Args: cobra.NoArgs,
+	}
+	flags := cmd.Flags()
+	verbose := flags.Bool("verbose", false, "Enable verbose output")
+
+	var opts cli.BootstrapOpts
+	flags.StringVarP(&opts.Env, "env", "e", "", "Environment to use, as defined in the project")
+	flags.IntVar(&opts.ExpireDays, "expire-days", 0, "Expire source code after the number of days, 0 to never expire. Deployment records are kept")
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		dir, err := global.workdir()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		app := cli.NewApp(*verbose)
+		opts.AWS = global.AWS
+
+		ctx, cancel := interruptContext()
+		code := app.Bootstrap(ctx, dir, opts)
+		cancel()
+		os.Exit(code)
+	}
+
+	return cmd
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/func/func/cli"
+	"github.com/spf13/cobra"
+)
+
+func cacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local build cache",
+		Long: "Manage the local build cache.\n\n" +
+			"Built source code archives are cached by checksum, so unchanged source " +
+			"code is never built twice. Dependencies installed by builders are cached " +
+			"in the same directory.",
+	}
+	cmd.AddCommand(cachePruneCommand())
+	return cmd
+}
+
+func cachePruneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove cached build artifacts and dependencies",
+		Long: "Remove cached build artifacts and dependencies.\n\n" +
+			"Archives cached by an earlier version of func, with checksums that are " +
+			"no longer used, are always removed.",
+		Args: cobra.NoArgs,
+	}
+	flags := cmd.Flags()
+	verbose := flags.Bool("verbose", false, "Enable verbose output")
+
+	var opts cli.CachePruneOpts
+	flags.DurationVar(&opts.OlderThan, "older-than", 0, "Only remove entries not used within the duration, such as 168h")
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		app := cli.NewApp(*verbose)
+		os.Exit(app.PruneCache(opts))
+	}
+
+	return cmd
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/func/func/cli"
+	"github.com/spf13/cobra"
+)
+
+func convertCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "convert <template>",
+		Short: "Convert a CloudFormation template to resource configurations",
+		Long: "Convert a CloudFormation template to resource configurations.\n\n" +
+			"Resources are named by their logical id. Ref, Fn::GetAtt and Fn::Sub are " +
+			"converted to references. Resources, properties and functions that cannot " +
+			"be converted are added as comments.",
+		Args: cobra.ExactArgs(1),
+	}
+	flags := cmd.Flags()
+	verbose := flags.Bool("verbose", false, "Enable verbose output")
+
+	var opts cli.ConvertOpts
+	flags.StringVarP(&opts.Output, "output", "o", "", "File to write to, defaults to stdout")
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		app := cli.NewApp(*verbose)
+		os.Exit(app.Convert(args[0], opts))
+	}
+
+	return cmd
+}
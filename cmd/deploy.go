@@ -1,15 +1,15 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"os"
 
 	"github.com/func/func/cli"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
-func deployCommand() *cobra.Command {
+func deployCommand(global *globalFlags) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "deploy",
 		Short: "Deploy CloudFormation stack",
@@ -18,22 +18,37 @@ func deployCommand() *cobra.Command {
 	verbose := flags.Bool("verbose", false, "Enable verbose output")
 
 	var opts cli.DeploymentOpts
-	flags.StringVarP(&opts.StackName, "stack", "s", "", "CloudFormation stack name")
-	flags.StringVar(&opts.SourceBucket, "source-bucket", "", "S3 Bucket to use for source code")
+	deploymentFlags(flags, &opts)
+	flags.StringVar(&opts.Target, "target", "", "Deployment target to deploy to, as defined in the project")
 
 	cmd.Run = func(cmd *cobra.Command, args []string) {
-		dir, err := os.Getwd()
+		dir, err := global.workdir()
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
 		}
 
 		app := cli.NewApp(*verbose)
+		opts.AWS = global.AWS
 
-		ctx := context.Background()
+		ctx, cancel := interruptContext()
 		code := app.DeployCloudFormation(ctx, dir, opts)
+		cancel()
 		os.Exit(code)
 	}
 
 	return cmd
 }
+
+// deploymentFlags adds flags for options that control how a stack is deployed.
+func deploymentFlags(flags *pflag.FlagSet, opts *cli.DeploymentOpts) {
+	flags.StringVarP(&opts.StackName, "stack", "s", "", "CloudFormation stack name")
+	flags.StringVarP(&opts.Env, "env", "e", "", "Environment to use, as defined in the project")
+	flags.StringVar(&opts.SourceBucket, "source-bucket", "", "S3 Bucket to use for source code")
+	flags.StringToStringVar(&opts.Tags, "tag", nil, "Tag to add to the stack and all its resources, as key=value")
+	flags.StringVar(&opts.RoleARN, "role-arn", "", "IAM role for CloudFormation to assume when deploying the stack")
+	flags.StringSliceVar(&opts.NotificationARNs, "notification-arn", nil, "SNS topic ARN to send stack events to")
+	flags.StringSliceVar(&opts.RollbackAlarms, "rollback-alarm", nil, "CloudWatch alarm ARN that triggers a rollback")
+	flags.DurationVar(&opts.RollbackMonitoring, "rollback-monitoring-time", 0, "Time to monitor rollback alarms after deployment")
+	flags.BoolVar(&opts.TerminationProtection, "termination-protection", false, "Enable termination protection on the stack")
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/func/func/cli"
+	"github.com/spf13/cobra"
+)
+
+func typesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "types",
+		Short: "List supported resource types",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			app := cli.NewApp(false)
+			os.Exit(app.Types())
+		},
+	}
+	return cmd
+}
+
+func docsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "docs [<type>...]",
+		Short: "Show documentation for resource types",
+		Long: "Show the inputs and outputs of resource types.\n\n" +
+			"With --json, a JSON Schema of the resource inputs is printed. If no type " +
+			"is given, the schemas of all types are printed, keyed by type.\n\n" +
+			"With --markdown-dir, a markdown reference page is written for each type. If " +
+			"no type is given, pages are written for all types.",
+	}
+	flags := cmd.Flags()
+
+	var opts cli.DocsOpts
+	flags.BoolVar(&opts.JSON, "json", false, "Print a JSON Schema")
+	flags.StringVar(&opts.MarkdownDir, "markdown-dir", "", "Write markdown reference pages to the directory")
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		app := cli.NewApp(false)
+		os.Exit(app.Docs(args, opts))
+	}
+
+	return cmd
+}
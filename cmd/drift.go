@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/func/func/cli"
+	"github.com/spf13/cobra"
+)
+
+func driftCommand(global *globalFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "Detect changes made to resources outside of deployments",
+		Long: "Detect changes made to deployed resources outside of deployments.\n\n" +
+			"Exit codes:\n" +
+			"  0  No drift detected\n" +
+			"  1  Error detecting drift\n" +
+			"  2  Invalid configuration\n" +
+			fmt.Sprintf("  %d  Drift detected\n", cli.DriftDetected),
+	}
+	flags := cmd.Flags()
+	verbose := flags.Bool("verbose", false, "Enable verbose output")
+
+	var opts cli.DriftOpts
+	flags.StringVarP(&opts.StackName, "stack", "s", "", "CloudFormation stack name")
+	flags.StringVarP(&opts.Env, "env", "e", "", "Environment to use, as defined in the project")
+	flags.StringVar(&opts.Format, "format", "text", "Output format (text, json)")
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		dir, err := global.workdir()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		app := cli.NewApp(*verbose)
+		opts.AWS = global.AWS
+
+		ctx, cancel := interruptContext()
+		code := app.DetectDrift(ctx, dir, opts)
+		cancel()
+		os.Exit(code)
+	}
+
+	return cmd
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/func/func/cli"
+	"github.com/spf13/cobra"
+)
+
+func fmtCommand(global *globalFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fmt",
+		Short: "Format resource configuration files",
+		Long: "Format resource configuration files in canonical style.\n\n" +
+			"All configuration files in the project are formatted. Meta arguments, " +
+			"such as type and source, are moved to the top of each resource. The " +
+			"names of changed files are printed.",
+	}
+	flags := cmd.Flags()
+	verbose := flags.Bool("verbose", false, "Enable verbose output")
+
+	var opts cli.FormatOpts
+	flags.BoolVar(&opts.Check, "check", false, "Do not write files, exit with 1 if any file is not formatted")
+	flags.BoolVar(&opts.Diff, "diff", false, "Print changes to files")
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		dir, err := global.workdir()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		app := cli.NewApp(*verbose)
+		os.Exit(app.Format(dir, opts))
+	}
+
+	return cmd
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"os"
 
@@ -9,7 +8,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func generateCommand() *cobra.Command {
+func generateCommand(global *globalFlags) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "generate",
 		Short: "Generate CloudFormation template",
@@ -19,20 +18,25 @@ func generateCommand() *cobra.Command {
 
 	var opts cli.GenerateCloudFormationOpts
 	flags.StringVarP(&opts.Format, "format", "f", "yaml", "Output format")
+	flags.StringVarP(&opts.Env, "env", "e", "", "Environment to use, as defined in the project")
 	flags.StringVar(&opts.SourceBucket, "source-bucket", "", "S3 Bucket to use for source code")
 	flags.BoolVar(&opts.ProcessSource, "process-source", false, "Build and upload source code if needed")
+	flags.BoolVar(&opts.Offline, "offline", false, "Only build source code to the local cache, do not upload it")
+	flags.StringVar(&opts.SourceDir, "source-dir", "", "Store source code in a local directory instead of the source bucket")
 
 	cmd.Run = func(cmd *cobra.Command, args []string) {
-		dir, err := os.Getwd()
+		dir, err := global.workdir()
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
 		}
 
 		app := cli.NewApp(*verbose)
+		opts.AWS = global.AWS
 
-		ctx := context.Background()
+		ctx, cancel := interruptContext()
 		code := app.GenerateCloudFormation(ctx, dir, opts)
+		cancel()
 		os.Exit(code)
 	}
 
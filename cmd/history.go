@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/func/func/cli"
+	"github.com/spf13/cobra"
+)
+
+func historyCommand(global *globalFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List previous deployments",
+	}
+	flags := cmd.Flags()
+	verbose := flags.Bool("verbose", false, "Enable verbose output")
+
+	var opts cli.HistoryOpts
+	flags.StringVarP(&opts.StackName, "stack", "s", "", "CloudFormation stack name")
+	flags.StringVarP(&opts.Env, "env", "e", "", "Environment to use, as defined in the project")
+	flags.StringVar(&opts.SourceBucket, "source-bucket", "", "S3 Bucket deployments are recorded in")
+	flags.IntVarP(&opts.Limit, "limit", "n", 20, "Maximum number of deployments to list, 0 for all")
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		dir, err := global.workdir()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		app := cli.NewApp(*verbose)
+		opts.AWS = global.AWS
+
+		ctx, cancel := interruptContext()
+		code := app.History(ctx, dir, opts)
+		cancel()
+		os.Exit(code)
+	}
+
+	return cmd
+}
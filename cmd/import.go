@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/func/func/cli"
+	"github.com/spf13/cobra"
+)
+
+func importCommand(global *globalFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <resource-name> <physical-id> [<resource-name> <physical-id>...]",
+		Short: "Import existing resources into the stack",
+		Long: "Import existing resources into the stack.\n\n" +
+			"The resources must be defined in the configuration. The physical id is the " +
+			"identifier of the existing resource, such as the function name for a Lambda " +
+			"function. Resources that are identified by multiple properties take the id as " +
+			"key=value pairs, for example ApiId=abc123,StageName=prod.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 || len(args)%2 != 0 {
+				return fmt.Errorf("requires pairs of resource name and physical id")
+			}
+			return nil
+		},
+	}
+	flags := cmd.Flags()
+	verbose := flags.Bool("verbose", false, "Enable verbose output")
+
+	var opts cli.ImportOpts
+	deploymentFlags(flags, &opts.DeploymentOpts)
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		dir, err := global.workdir()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		opts.Resources = make(map[string]string, len(args)/2)
+		for i := 0; i < len(args); i += 2 {
+			opts.Resources[args[i]] = args[i+1]
+		}
+
+		app := cli.NewApp(*verbose)
+		opts.AWS = global.AWS
+
+		ctx, cancel := interruptContext()
+		code := app.Import(ctx, dir, opts)
+		cancel()
+		os.Exit(code)
+	}
+
+	return cmd
+}
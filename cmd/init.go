@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/func/func/cli"
+	"github.com/func/func/scaffold"
+	"github.com/spf13/cobra"
+)
+
+func initCommand(global *globalFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create a new project",
+		Long: "Create a new project from a template.\n\n" +
+			"The project contains a Lambda function with an execution role and a " +
+			"handler stub. Existing files are never overwritten.",
+		Args: cobra.NoArgs,
+	}
+	flags := cmd.Flags()
+	verbose := flags.Bool("verbose", false, "Enable verbose output")
+
+	var opts cli.InitOpts
+	flags.StringVarP(&opts.Template, "template", "t", "go", fmt.Sprintf("Template to use (%s)", strings.Join(scaffold.Templates(), ", ")))
+	flags.StringVar(&opts.Name, "name", "", "Project name, defaults to the directory name")
+	flags.BoolVar(&opts.API, "api", false, "Add an HTTP API that invokes the function")
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		dir, err := global.workdir()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		app := cli.NewApp(*verbose)
+		os.Exit(app.Init(dir, opts))
+	}
+
+	return cmd
+}
@@ -3,10 +3,26 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/func/func/cli"
 	"github.com/spf13/cobra"
 )
 
+// globalFlags contains flags that are shared by all commands.
+type globalFlags struct {
+	Dir string
+	AWS cli.AWSOpts
+}
+
+// workdir returns the directory to run the command in.
+func (g *globalFlags) workdir() (string, error) {
+	if g.Dir != "" {
+		return filepath.Abs(g.Dir)
+	}
+	return os.Getwd()
+}
+
 // Exec executes the main command.
 func Exec() {
 	cmd := &cobra.Command{
@@ -16,9 +32,34 @@ func Exec() {
 		},
 	}
 
+	var global globalFlags
+	flags := cmd.PersistentFlags()
+	flags.StringVarP(&global.Dir, "dir", "C", "", "Run as if func was started in the given directory")
+	flags.StringVar(&global.AWS.Profile, "profile", "", "AWS profile to use from the shared config")
+	flags.StringVar(&global.AWS.Region, "region", "", "AWS region to use")
+	flags.StringVar(&global.AWS.AssumeRoleARN, "assume-role-arn", "", "ARN of an IAM role to assume")
+	flags.StringVar(&global.AWS.ExternalID, "external-id", "", "External ID to use when assuming a role")
+	flags.StringVar(&global.AWS.RoleSessionName, "role-session-name", "", "Session name to use when assuming a role")
+	flags.StringVar(&global.AWS.S3Endpoint, "s3-endpoint", "", "Custom endpoint URL of an S3 compatible service to store source code in")
+	flags.BoolVar(&global.AWS.S3PathStyle, "s3-path-style", false, "Use path-style addressing for S3")
+
 	cmd.AddCommand(versionCommand())
-	cmd.AddCommand(generateCommand())
-	cmd.AddCommand(deployCommand())
+	cmd.AddCommand(initCommand(&global))
+	cmd.AddCommand(validateCommand(&global))
+	cmd.AddCommand(typesCommand())
+	cmd.AddCommand(docsCommand())
+	cmd.AddCommand(bootstrapCommand(&global))
+	cmd.AddCommand(generateCommand(&global))
+	cmd.AddCommand(deployCommand(&global))
+	cmd.AddCommand(statusCommand(&global))
+	cmd.AddCommand(watchCommand(&global))
+	cmd.AddCommand(historyCommand(&global))
+	cmd.AddCommand(rollbackCommand(&global))
+	cmd.AddCommand(driftCommand(&global))
+	cmd.AddCommand(importCommand(&global))
+	cmd.AddCommand(convertCommand())
+	cmd.AddCommand(fmtCommand(&global))
+	cmd.AddCommand(cacheCommand())
 
 	_ = cmd.Execute()
 }
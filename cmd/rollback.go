@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/func/func/cli"
+	"github.com/spf13/cobra"
+)
+
+func rollbackCommand(global *globalFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Redeploy a previous deployment",
+		Long: "Redeploy a previous deployment, as listed by history.\n\n" +
+			"The recorded template is deployed with the previously uploaded source code. " +
+			"Source code is not built again.",
+	}
+	flags := cmd.Flags()
+	verbose := flags.Bool("verbose", false, "Enable verbose output")
+
+	var opts cli.RollbackOpts
+	deploymentFlags(flags, &opts.DeploymentOpts)
+	flags.StringVar(&opts.To, "to", "", "Id of the deployment to roll back to")
+	_ = cmd.MarkFlagRequired("to")
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		dir, err := global.workdir()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		app := cli.NewApp(*verbose)
+		opts.AWS = global.AWS
+
+		ctx, cancel := interruptContext()
+		code := app.Rollback(ctx, dir, opts)
+		cancel()
+		os.Exit(code)
+	}
+
+	return cmd
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// interruptContext returns a context that is cancelled when the process is
+// interrupted. The command is expected to clean up and exit after the context
+// has been cancelled. A second interrupt exits the process immediately.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+			signal.Stop(sig)
+			return
+		}
+		<-sig
+		os.Exit(130)
+	}()
+	return ctx, cancel
+}
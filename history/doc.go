@@ -0,0 +1,3 @@
+// Package history records deployments so they can be listed and rolled back
+// to.
+package history
@@ -0,0 +1,105 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/func/func/cloudformation"
+)
+
+// A Record describes a successful deployment.
+type Record struct {
+	ID     string    `json:"id"`
+	Stack  string    `json:"stack"`
+	Time   time.Time `json:"time"`
+	User   string    `json:"user,omitempty"`
+	Commit string    `json:"commit,omitempty"`
+
+	// Rollback is set to the id of the deployment that was rolled back to, if
+	// the deployment was a rollback.
+	Rollback string `json:"rollback,omitempty"`
+
+	Template  *cloudformation.Template `json:"template"`
+	Sources   map[string]string        `json:"sources,omitempty"`   // Resource name -> source key
+	Resources map[string]string        `json:"resources,omitempty"` // Logical id -> resource name
+}
+
+// Storage is the storage records are stored in. Implemented by source.S3.
+type Storage interface {
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	List(ctx context.Context, prefix string) ([]string, error)
+	Upload(ctx context.Context, key string, body io.Reader) error
+}
+
+// Prefix is the key prefix for stored records.
+const Prefix = "deployments/"
+
+// A Store stores deployment records.
+type Store struct {
+	Storage Storage
+}
+
+func key(stack, id string) string {
+	return Prefix + stack + "/" + id + ".json"
+}
+
+// Put stores a record.
+func (s *Store) Put(ctx context.Context, rec *Record) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	return s.Storage.Upload(ctx, key(rec.Stack, rec.ID), bytes.NewReader(b))
+}
+
+// Get returns a single record for a stack.
+func (s *Store) Get(ctx context.Context, stack, id string) (*Record, error) {
+	r, err := s.Storage.Get(ctx, key(stack, id))
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", id, err)
+	}
+	var rec Record
+	err = json.NewDecoder(r).Decode(&rec)
+	_ = r.Close()
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", id, err)
+	}
+	return &rec, nil
+}
+
+// List returns the records for a stack, newest first. If limit is larger than
+// zero, at most limit records are returned.
+func (s *Store) List(ctx context.Context, stack string, limit int) ([]*Record, error) {
+	prefix := Prefix + stack + "/"
+	keys, err := s.Storage.List(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+	ids := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if !strings.HasSuffix(k, ".json") {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(strings.TrimPrefix(k, prefix), ".json"))
+	}
+	// Ids are time based, sort descending to get newest first.
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+	out := make([]*Record, len(ids))
+	for i, id := range ids {
+		rec, err := s.Get(ctx, stack, id)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = rec
+	}
+	return out, nil
+}
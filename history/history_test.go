@@ -0,0 +1,99 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/func/func/cloudformation"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStore(t *testing.T) {
+	mem := memStorage{}
+	store := &Store{Storage: mem}
+	ctx := context.Background()
+
+	records := []*Record{
+		{ID: "func-20200101-100000", Stack: "foo", User: "alice"},
+		{ID: "func-20200103-100000", Stack: "foo", User: "bob", Commit: "abc123"},
+		{ID: "func-20200102-100000", Stack: "foo", Rollback: "func-20200101-100000"},
+		{ID: "func-20200104-100000", Stack: "bar"},
+	}
+	for _, rec := range records {
+		rec.Time = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		rec.Template = &cloudformation.Template{AWSTemplateFormatVersion: "2010-09-09"}
+		rec.Sources = map[string]string{"func": "abc.zip"}
+		if err := store.Put(ctx, rec); err != nil {
+			t.Fatalf("Put() err = %v", err)
+		}
+	}
+	mem["deployments/foo/README"] = nil // Ignored
+
+	opts := []cmp.Option{cmp.AllowUnexported(cloudformation.Template{})}
+
+	got, err := store.List(ctx, "foo", 0)
+	if err != nil {
+		t.Fatalf("List() err = %v", err)
+	}
+	want := []*Record{records[1], records[2], records[0]}
+	if diff := cmp.Diff(got, want, opts...); diff != "" {
+		t.Errorf("List() diff (-got +want)\n%s", diff)
+	}
+
+	got, err = store.List(ctx, "foo", 1)
+	if err != nil {
+		t.Fatalf("List() err = %v", err)
+	}
+	if diff := cmp.Diff(got, want[:1], opts...); diff != "" {
+		t.Errorf("List() with limit diff (-got +want)\n%s", diff)
+	}
+
+	rec, err := store.Get(ctx, "bar", "func-20200104-100000")
+	if err != nil {
+		t.Fatalf("Get() err = %v", err)
+	}
+	if diff := cmp.Diff(rec, records[3], opts...); diff != "" {
+		t.Errorf("Get() diff (-got +want)\n%s", diff)
+	}
+
+	if _, err := store.Get(ctx, "bar", "nonexisting"); err == nil {
+		t.Errorf("Get() want error for nonexisting record")
+	}
+}
+
+type memStorage map[string][]byte
+
+func (m memStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	b, ok := m[key]
+	if !ok {
+		return nil, fmt.Errorf("not found")
+	}
+	return ioutil.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (m memStorage) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	for k := range m {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (m memStorage) Upload(ctx context.Context, key string, body io.Reader) error {
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	m[key] = b
+	return nil
+}
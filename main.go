@@ -1,5 +1,7 @@
 package main
 
+//go:generate go run . docs --markdown-dir docs/resources
+
 import (
 	"github.com/func/func/cmd"
 )
@@ -0,0 +1,2 @@
+// Package project provides project level configuration.
+package project
@@ -0,0 +1,406 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Filename is the name of the file that contains the project block.
+const Filename = "func.hcl"
+
+// DefaultEnv is the environment name available in resource configurations
+// when no environment has been selected.
+const DefaultEnv = "default"
+
+// A Project contains settings that apply to the whole project.
+//
+// All fields are optional. Values set with command line flags take precedence
+// over values set in the project.
+type Project struct {
+	Name         string
+	Stack        string
+	SourceBucket string
+	Region       string
+	Profile      string
+
+	// Vars are the default values of variables, used if the selected
+	// environment does not set them.
+	Vars map[string]cty.Value
+
+	// Environments contains the named environments the project can be
+	// deployed to.
+	Environments []*Environment
+
+	// Targets contains the deployment targets. If set, the project is
+	// deployed to all targets, unless a single target is selected.
+	Targets []*Target
+
+	// Env is the selected environment. Nil if no environment has been
+	// selected.
+	Env *Environment
+
+	// Dir is the root directory of the project, the directory containing the
+	// project file.
+	Dir        string
+	Definition hcl.Range
+}
+
+// An Environment contains overrides for deploying the project to a specific
+// environment, such as dev or prod.
+type Environment struct {
+	Name         string
+	Stack        string
+	SourceBucket string
+	Region       string
+	Profile      string
+
+	// Vars are variables that are available in resource configurations as
+	// var.<name>.
+	Vars map[string]cty.Value
+
+	Definition hcl.Range
+}
+
+// A Target is a deployment target, a stack in a specific account and region.
+//
+// Values that are not set in the target are inherited from the project.
+type Target struct {
+	Name          string
+	Account       string
+	Region        string
+	Stack         string
+	SourceBucket  string
+	Profile       string
+	AssumeRoleARN string
+
+	Definition hcl.Range
+}
+
+var projectSpec = hcldec.ObjectSpec{
+	"name":          &hcldec.AttrSpec{Name: "name", Type: cty.String},
+	"stack":         &hcldec.AttrSpec{Name: "stack", Type: cty.String},
+	"source_bucket": &hcldec.AttrSpec{Name: "source_bucket", Type: cty.String},
+	"region":        &hcldec.AttrSpec{Name: "region", Type: cty.String},
+	"profile":       &hcldec.AttrSpec{Name: "profile", Type: cty.String},
+	"vars":          &hcldec.AttrSpec{Name: "vars", Type: cty.DynamicPseudoType},
+}
+
+var environmentSpec = hcldec.ObjectSpec{
+	"stack":         &hcldec.AttrSpec{Name: "stack", Type: cty.String},
+	"source_bucket": &hcldec.AttrSpec{Name: "source_bucket", Type: cty.String},
+	"region":        &hcldec.AttrSpec{Name: "region", Type: cty.String},
+	"profile":       &hcldec.AttrSpec{Name: "profile", Type: cty.String},
+	"vars":          &hcldec.AttrSpec{Name: "vars", Type: cty.DynamicPseudoType},
+}
+
+var targetSpec = hcldec.ObjectSpec{
+	"account":         &hcldec.AttrSpec{Name: "account", Type: cty.String},
+	"region":          &hcldec.AttrSpec{Name: "region", Type: cty.String, Required: true},
+	"stack":           &hcldec.AttrSpec{Name: "stack", Type: cty.String},
+	"source_bucket":   &hcldec.AttrSpec{Name: "source_bucket", Type: cty.String},
+	"profile":         &hcldec.AttrSpec{Name: "profile", Type: cty.String},
+	"assume_role_arn": &hcldec.AttrSpec{Name: "assume_role_arn", Type: cty.String},
+}
+
+// Environment returns the environment with the given name. Returns nil if no
+// such environment exists.
+func (p *Project) Environment(name string) *Environment {
+	for _, env := range p.Environments {
+		if env.Name == name {
+			return env
+		}
+	}
+	return nil
+}
+
+// Select returns a copy of the project with the given environment selected.
+// Values set in the environment override the values set in the project.
+func (p *Project) Select(name string) (*Project, error) {
+	env := p.Environment(name)
+	if env == nil {
+		names := make([]string, len(p.Environments))
+		for i, e := range p.Environments {
+			names[i] = e.Name
+		}
+		return nil, fmt.Errorf("environment %q not found, available: %v", name, names)
+	}
+	out := *p
+	out.Env = env
+	override(&out.Stack, env.Stack)
+	override(&out.SourceBucket, env.SourceBucket)
+	override(&out.Region, env.Region)
+	override(&out.Profile, env.Profile)
+	return &out, nil
+}
+
+func override(target *string, value string) {
+	if value != "" {
+		*target = value
+	}
+}
+
+// EvalContext returns the context to use when evaluating resource
+// configurations in the project.
+//
+// The name of the selected environment is available as env.name and variables
+// as var.<name>. Variables set in the environment override the project
+// defaults. If no environment has been selected, env.name is DefaultEnv and
+// only the defaults are set.
+//
+// The context can be created for a nil project, when resources are loaded
+// without a project.
+func (p *Project) EvalContext() *hcl.EvalContext {
+	name := DefaultEnv
+	vars := map[string]cty.Value{}
+	if p != nil {
+		for k, v := range p.Vars {
+			vars[k] = v
+		}
+		if p.Env != nil {
+			name = p.Env.Name
+			for k, v := range p.Env.Vars {
+				vars[k] = v
+			}
+		}
+	}
+	return &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"env": cty.ObjectVal(map[string]cty.Value{
+				"name": cty.StringVal(name),
+			}),
+			"var": cty.ObjectVal(vars),
+		},
+	}
+}
+
+// A Loader finds and loads project configurations.
+type Loader struct {
+	parser *hclparse.Parser
+}
+
+// Find finds the project by walking up from the given directory until a
+// project file with a project block is found. If no project is found, nil is
+// returned.
+func (l *Loader) Find(dir string) (*Project, hcl.Diagnostics) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Could not resolve project directory",
+			Detail:   err.Error(),
+		}}
+	}
+	for {
+		filename := filepath.Join(dir, Filename)
+		if _, err := os.Stat(filename); err == nil {
+			p, diags := l.Load(filename)
+			if diags.HasErrors() || p != nil {
+				return p, diags
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			// Reached root
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// Load loads the project from the given file. If the file does not contain a
+// project block, nil is returned.
+func (l *Loader) Load(filename string) (*Project, hcl.Diagnostics) {
+	if l.parser == nil {
+		l.parser = hclparse.NewParser()
+	}
+	f, diags := l.parser.ParseHCLFile(filename)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return Decode(f.Body, filepath.Dir(filename))
+}
+
+// Files returns a map of all loaded files, keyed by file name.
+func (l *Loader) Files() map[string]*hcl.File {
+	if l.parser == nil {
+		return nil
+	}
+	return l.parser.Files()
+}
+
+// Decode decodes the project block from a configuration body. Other blocks
+// in the body are ignored. If the body does not contain a project block, nil
+// is returned.
+func Decode(body hcl.Body, dir string) (*Project, hcl.Diagnostics) {
+	schema := &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "project"},
+		},
+	}
+	content, _, diags := body.PartialContent(schema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	blocks := content.Blocks.OfType("project")
+	if len(blocks) == 0 {
+		return nil, diags
+	}
+	for _, b := range blocks[1:] {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Duplicate project block",
+			Detail: "Only one project block is allowed. The project was previously " +
+				"defined in " + blocks[0].DefRange.String() + ".",
+			Subject: b.DefRange.Ptr(),
+		})
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	block := blocks[0]
+	nestedSchema := &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "environment", LabelNames: []string{"name"}},
+			{Type: "target", LabelNames: []string{"name"}},
+		},
+	}
+	nested, body, morediags := block.Body.PartialContent(nestedSchema)
+	diags = append(diags, morediags...)
+
+	val, morediags := hcldec.Decode(body, projectSpec, nil)
+	diags = append(diags, morediags...)
+	vars, morediags := decodeVars(body, projectSpec, val)
+	diags = append(diags, morediags...)
+
+	var envs []*Environment
+	for _, b := range nested.Blocks.OfType("environment") {
+		env, morediags := decodeEnvironment(b)
+		diags = append(diags, morediags...)
+		if env == nil {
+			continue
+		}
+		for _, prev := range envs {
+			if prev.Name == env.Name {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate environment",
+					Detail:   fmt.Sprintf("Another environment named %q was defined on line %d.", env.Name, prev.Definition.Start.Line),
+					Subject:  b.DefRange.Ptr(),
+				})
+			}
+		}
+		envs = append(envs, env)
+	}
+
+	var targets []*Target
+	for _, b := range nested.Blocks.OfType("target") {
+		target, morediags := decodeTarget(b)
+		diags = append(diags, morediags...)
+		if target == nil {
+			continue
+		}
+		for _, prev := range targets {
+			if prev.Name == target.Name {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate target",
+					Detail:   fmt.Sprintf("Another target named %q was defined on line %d.", target.Name, prev.Definition.Start.Line),
+					Subject:  b.DefRange.Ptr(),
+				})
+			}
+		}
+		targets = append(targets, target)
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return &Project{
+		Name:         stringVal(val.GetAttr("name")),
+		Stack:        stringVal(val.GetAttr("stack")),
+		SourceBucket: stringVal(val.GetAttr("source_bucket")),
+		Region:       stringVal(val.GetAttr("region")),
+		Profile:      stringVal(val.GetAttr("profile")),
+		Vars:         vars,
+		Environments: envs,
+		Targets:      targets,
+		Dir:          dir,
+		Definition:   block.DefRange,
+	}, diags
+}
+
+func decodeEnvironment(block *hcl.Block) (*Environment, hcl.Diagnostics) {
+	val, diags := hcldec.Decode(block.Body, environmentSpec, nil)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	env := &Environment{
+		Name:         block.Labels[0],
+		Stack:        stringVal(val.GetAttr("stack")),
+		SourceBucket: stringVal(val.GetAttr("source_bucket")),
+		Region:       stringVal(val.GetAttr("region")),
+		Profile:      stringVal(val.GetAttr("profile")),
+		Definition:   block.DefRange,
+	}
+	vars, morediags := decodeVars(block.Body, environmentSpec, val)
+	diags = append(diags, morediags...)
+	if morediags.HasErrors() {
+		return nil, diags
+	}
+	env.Vars = vars
+	return env, diags
+}
+
+// decodeVars returns the variables set in the vars attribute of a decoded
+// block.
+func decodeVars(body hcl.Body, spec hcldec.ObjectSpec, val cty.Value) (map[string]cty.Value, hcl.Diagnostics) {
+	if val.IsNull() {
+		return nil, nil
+	}
+	vars := val.GetAttr("vars")
+	if vars.IsNull() {
+		return nil, nil
+	}
+	if !vars.Type().IsObjectType() && !vars.Type().IsMapType() {
+		rng := hcldec.SourceRange(body, spec["vars"])
+		return nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid vars",
+			Detail:   "Variables must be set as an object, for example { memory = 512 }.",
+			Subject:  rng.Ptr(),
+		}}
+	}
+	return vars.AsValueMap(), nil
+}
+
+func decodeTarget(block *hcl.Block) (*Target, hcl.Diagnostics) {
+	val, diags := hcldec.Decode(block.Body, targetSpec, nil)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return &Target{
+		Name:          block.Labels[0],
+		Account:       stringVal(val.GetAttr("account")),
+		Region:        stringVal(val.GetAttr("region")),
+		Stack:         stringVal(val.GetAttr("stack")),
+		SourceBucket:  stringVal(val.GetAttr("source_bucket")),
+		Profile:       stringVal(val.GetAttr("profile")),
+		AssumeRoleARN: stringVal(val.GetAttr("assume_role_arn")),
+		Definition:    block.DefRange,
+	}, diags
+}
+
+func stringVal(v cty.Value) string {
+	if v.IsNull() || !v.IsKnown() {
+		return ""
+	}
+	return v.AsString()
+}
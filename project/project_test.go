@@ -0,0 +1,397 @@
+package project
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"golang.org/x/tools/txtar"
+)
+
+func TestLoader_Find(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    string
+		dir      string
+		want     *Project
+		wantErrs bool
+	}{
+		{
+			name: "Project",
+			files: `
+-- func.hcl --
+project {
+	name          = "test"
+	stack         = "test-stack"
+	source_bucket = "test-bucket"
+	region        = "eu-west-1"
+	profile       = "test-profile"
+}
+`,
+			dir: ".",
+			want: &Project{
+				Name:         "test",
+				Stack:        "test-stack",
+				SourceBucket: "test-bucket",
+				Region:       "eu-west-1",
+				Profile:      "test-profile",
+				Dir:          "<DIR>",
+			},
+		},
+		{
+			name: "Parent",
+			files: `
+-- func.hcl --
+project {
+	stack = "test-stack"
+}
+-- a/b/c/resources.hcl --
+resource "foo" {}
+`,
+			dir: "a/b/c",
+			want: &Project{
+				Stack: "test-stack",
+				Dir:   "<DIR>",
+			},
+		},
+		{
+			name: "SkipWithoutProject",
+			files: `
+-- func.hcl --
+project {
+	stack = "test-stack"
+}
+-- sub/func.hcl --
+resource "foo" {}
+`,
+			dir: "sub",
+			want: &Project{
+				Stack: "test-stack",
+				Dir:   "<DIR>",
+			},
+		},
+		{
+			name: "IgnoreResources",
+			files: `
+-- func.hcl --
+project {
+	stack = "test-stack"
+}
+
+resource "foo" {
+	type = "bar"
+}
+`,
+			dir: ".",
+			want: &Project{
+				Stack: "test-stack",
+				Dir:   "<DIR>",
+			},
+		},
+		{
+			name: "Environments",
+			files: `
+-- func.hcl --
+project {
+	stack = "test"
+	vars = {
+		memory = 128
+	}
+
+	environment "dev" {}
+
+	environment "prod" {
+		stack  = "test-prod"
+		region = "us-east-1"
+		vars = {
+			memory = 512
+		}
+	}
+}
+`,
+			dir: ".",
+			want: &Project{
+				Stack: "test",
+				Vars: map[string]cty.Value{
+					"memory": cty.NumberIntVal(128),
+				},
+				Environments: []*Environment{
+					{Name: "dev"},
+					{
+						Name:   "prod",
+						Stack:  "test-prod",
+						Region: "us-east-1",
+						Vars: map[string]cty.Value{
+							"memory": cty.NumberIntVal(512),
+						},
+					},
+				},
+				Dir: "<DIR>",
+			},
+		},
+		{
+			name: "Targets",
+			files: `
+-- func.hcl --
+project {
+	stack = "test"
+
+	target "us" {
+		region        = "us-east-1"
+		source_bucket = "bucket-us-east-1"
+	}
+
+	target "eu" {
+		account         = "123456789012"
+		region          = "eu-west-1"
+		stack           = "test-eu"
+		source_bucket   = "bucket-eu-west-1"
+		assume_role_arn = "arn:aws:iam::123456789012:role/deploy"
+	}
+}
+`,
+			dir: ".",
+			want: &Project{
+				Stack: "test",
+				Targets: []*Target{
+					{Name: "us", Region: "us-east-1", SourceBucket: "bucket-us-east-1"},
+					{
+						Name:          "eu",
+						Account:       "123456789012",
+						Region:        "eu-west-1",
+						Stack:         "test-eu",
+						SourceBucket:  "bucket-eu-west-1",
+						AssumeRoleARN: "arn:aws:iam::123456789012:role/deploy",
+					},
+				},
+				Dir: "<DIR>",
+			},
+		},
+		{
+			name: "TargetWithoutRegion",
+			files: `
+-- func.hcl --
+project {
+	target "us" {}
+}
+`,
+			dir:      ".",
+			wantErrs: true,
+		},
+		{
+			name: "DuplicateEnvironment",
+			files: `
+-- func.hcl --
+project {
+	environment "dev" {}
+	environment "dev" {}
+}
+`,
+			dir:      ".",
+			wantErrs: true,
+		},
+		{
+			name: "NoProject",
+			files: `
+-- resources.hcl --
+resource "foo" {}
+`,
+			dir:  ".",
+			want: nil,
+		},
+		{
+			name: "Duplicate",
+			files: `
+-- func.hcl --
+project {
+	stack = "a"
+}
+project {
+	stack = "b"
+}
+`,
+			dir:      ".",
+			wantErrs: true,
+		},
+		{
+			name: "UnsupportedAttribute",
+			files: `
+-- func.hcl --
+project {
+	foo = "bar"
+}
+`,
+			dir:      ".",
+			wantErrs: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := tempdir(t)
+			writeTxtar(t, dir, tc.files)
+
+			l := &Loader{}
+			got, diags := l.Find(filepath.Join(dir, tc.dir))
+			if diags.HasErrors() != tc.wantErrs {
+				t.Fatalf("Diagnostics = %v, want errors = %t", diags, tc.wantErrs)
+			}
+			if tc.wantErrs {
+				return
+			}
+			if tc.want != nil && tc.want.Dir == "<DIR>" {
+				tc.want.Dir = dir
+			}
+			opts := []cmp.Option{
+				cmpopts.IgnoreTypes(hcl.Range{}),
+				cmp.Comparer(func(a, b cty.Value) bool { return a.RawEquals(b) }),
+			}
+			if diff := cmp.Diff(got, tc.want, opts...); diff != "" {
+				t.Errorf("Diff (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProject_Select(t *testing.T) {
+	p := &Project{
+		Stack:        "test",
+		SourceBucket: "bucket",
+		Region:       "eu-west-1",
+		Environments: []*Environment{
+			{
+				Name:   "prod",
+				Stack:  "test-prod",
+				Region: "us-east-1",
+				Vars: map[string]cty.Value{
+					"memory": cty.NumberIntVal(512),
+				},
+			},
+		},
+	}
+
+	got, err := p.Select("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Project{
+		Stack:        "test-prod",
+		SourceBucket: "bucket",
+		Region:       "us-east-1",
+		Environments: p.Environments,
+		Env:          p.Environments[0],
+	}
+	if diff := cmp.Diff(got, want, cmp.Comparer(func(a, b cty.Value) bool { return a.RawEquals(b) })); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+
+	ctx := got.EvalContext()
+	if name := ctx.Variables["env"].GetAttr("name"); !name.RawEquals(cty.StringVal("prod")) {
+		t.Errorf("env.name = %#v, want prod", name)
+	}
+	if mem := ctx.Variables["var"].GetAttr("memory"); !mem.RawEquals(cty.NumberIntVal(512)) {
+		t.Errorf("var.memory = %#v, want 512", mem)
+	}
+
+	if _, err := p.Select("nonexisting"); err == nil {
+		t.Errorf("Want error for non-existing environment")
+	}
+}
+
+func TestProject_EvalContext(t *testing.T) {
+	p := &Project{
+		Vars: map[string]cty.Value{
+			"memory":  cty.NumberIntVal(128),
+			"timeout": cty.NumberIntVal(3),
+		},
+		Environments: []*Environment{
+			{
+				Name: "prod",
+				Vars: map[string]cty.Value{
+					"memory": cty.NumberIntVal(512),
+				},
+			},
+		},
+	}
+	prod, err := p.Select("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		project *Project
+		env     string
+		vars    map[string]cty.Value
+	}{
+		{
+			name:    "NoProject",
+			project: nil,
+			env:     DefaultEnv,
+			vars:    map[string]cty.Value{},
+		},
+		{
+			name:    "NoEnvironment",
+			project: p,
+			env:     DefaultEnv,
+			vars:    p.Vars,
+		},
+		{
+			name:    "Environment",
+			project: prod,
+			env:     "prod",
+			vars: map[string]cty.Value{
+				"memory":  cty.NumberIntVal(512),
+				"timeout": cty.NumberIntVal(3),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := tc.project.EvalContext()
+			if name := ctx.Variables["env"].GetAttr("name"); !name.RawEquals(cty.StringVal(tc.env)) {
+				t.Errorf("env.name = %#v, want %s", name, tc.env)
+			}
+			got := ctx.Variables["var"].AsValueMap()
+			if got == nil {
+				got = map[string]cty.Value{}
+			}
+			if diff := cmp.Diff(got, tc.vars, cmp.Comparer(func(a, b cty.Value) bool { return a.RawEquals(b) })); diff != "" {
+				t.Errorf("Diff (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func tempdir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Helper()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	// Resolve symlinks, such as /tmp on macOS.
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func writeTxtar(t *testing.T, dir, input string) {
+	archive := txtar.Parse([]byte(input))
+	for _, f := range archive.Files {
+		filename := filepath.Join(dir, f.Name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filename, f.Data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
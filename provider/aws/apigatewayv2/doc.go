@@ -1,2 +1,4 @@
 // Package apigatewayv2 provides resources for AmazonApiGatewayV2.
 package apigatewayv2
+
+//go:generate go run ../docgen
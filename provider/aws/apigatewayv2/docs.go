@@ -0,0 +1,218 @@
+// Code generated by docgen. DO NOT EDIT.
+
+package apigatewayv2
+
+// Docs returns the documentation for Api and its fields.
+func (Api) Docs() map[string]string {
+	return map[string]string{
+		"":                         "Api manages AmazonApiGatewayV2 Apis.",
+		"CORS":                     "A CORS configuration. Supported only for HTTP APIs. See Configuring CORS for more information.",
+		"CORS.AllowCredentials":    "Specifies whether credentials are included in the CORS request. Supported only for HTTP APIs.",
+		"CORS.AllowHeaders":        "Represents a collection of allowed headers. Supported only for HTTP APIs.",
+		"CORS.AllowMethods":        "Represents a collection of allowed HTTP methods. Supported only for HTTP APIs.",
+		"CORS.AllowOrigins":        "Represents a collection of allowed origins. Supported only for HTTP APIs.",
+		"CORS.ExposeHeaders":       "Represents a collection of exposed headers. Supported only for HTTP APIs.",
+		"CORS.MaxAge":              "The number of seconds that the browser should cache preflight request results. Supported only for HTTP APIs.",
+		"Created":                  "The timestamp when the API was created.",
+		"CredentialsARN":           "This property is part of quick create. It specifies the credentials required for the integration, if any. For a Lambda integration, three options are available. To specify an IAM Role for API Gateway to assume, use the role's Amazon Resource Name (ARN). To require that the caller's identity be passed through from the request, specify arn:aws:iam::*:user/*. To use resource-based permissions on supported AWS services, specify null. Currently, this property is not used for HTTP integrations. Supported only for HTTP APIs.",
+		"Description":              "The description of the API.",
+		"DisableSchemaValidation":  "Avoid validating models when creating a deployment. Supported only for WebSocket APIs.",
+		"Endpoint":                 "The URI of the API, of the form {api-id}.execute-api.{region}.amazonaws.com. The stage name is typically appended to this URI to form a complete path to a deployed API stage.",
+		"ID":                       "The API ID.",
+		"ImportInfo":               "The validation information during API import. This may include particular properties of your OpenAPI definition which are ignored during import. Supported only for HTTP APIs.",
+		"KeySelection":             "An API key selection expression. Supported only for WebSocket APIs. See API Key Selection Expressions.",
+		"Name":                     "The name of the API.",
+		"Protocol":                 "The API protocol.",
+		"RouteKey":                 "This property is part of quick create. If you don't specify a routeKey, a default route of $default is created. The $default route acts as a catch-all for any request made to your API, for a particular stage. The $default route key can't be modified. You can add routes after creating the API, and you can update the route keys of additional routes. Supported only for HTTP APIs.",
+		"RouteSelectionExpression": "The route selection expression for the API. For HTTP APIs, the routeSelectionExpression must be ${request.method} ${request.path}. If not provided, this will be the default for HTTP APIs. This property is required for WebSocket APIs.",
+		"Tags":                     "The collection of tags. Each tag element is associated with a given resource.",
+		"Target":                   "This property is part of quick create. Quick create produces an API with an integration, a default catch-all route, and a default stage which is configured to automatically deploy changes. For HTTP integrations, specify a fully qualified URL. For Lambda integrations, specify a function ARN. The type of the integration will be HTTP_PROXY or AWS_PROXY, respectively. Supported only for HTTP APIs.",
+		"Version":                  "A version identifier for the API.",
+		"Warnings":                 "The warning messages reported when failonwarnings is turned on during API import.",
+	}
+}
+
+// Docs returns the documentation for ApiMapping and its fields.
+func (ApiMapping) Docs() map[string]string {
+	return map[string]string{
+		"":           "ApiMapping manages AmazonApiGatewayV2 ApiMappings.",
+		"API":        "The API identifier.",
+		"DomainName": "The domain name.",
+		"ID":         "The API mapping identifier.",
+		"Key":        "The API mapping key.",
+		"Stage":      "The API stage.",
+	}
+}
+
+// Docs returns the documentation for Authorizer and its fields.
+func (Authorizer) Docs() map[string]string {
+	return map[string]string{
+		"":                   "Authorizer manages AmazonApiGatewayV2 Authorizers.",
+		"API":                "The API identifier.",
+		"CredentialsARN":     "Specifies the required credentials as an IAM role for API Gateway to invoke the authorizer. To specify an IAM role for API Gateway to assume, use the role's Amazon Resource Name (ARN). To use resource-based permissions on the Lambda function, specify null. Supported only for REQUEST authorizers.",
+		"ID":                 "The authorizer identifier.",
+		"IdentifyValidation": "This parameter is not used.",
+		"IdentitySource":     "The identity source for which authorization is requested.For a REQUEST authorizer, this is optional. The value is a set of one or more mapping expressions of the specified request parameters. Currently, the identity source can be headers, query string parameters, stage variables, and context parameters. For example, if an Auth header and a Name query string parameter are defined as identity sources, this value is route.request.header.Auth, route.request.querystring.Name. These parameters will be used to perform runtime validation for Lambda-based authorizers by verifying all of the identity-related request parameters are present in the request, not null, and non-empty. Only when this is true does the authorizer invoke the authorizer Lambda function. Otherwise, it returns a 401 Unauthorized response without calling the Lambda function.For JWT, a single entry that specifies where to extract the JSON Web Token (JWT )from inbound requests. Currently only header-based and query parameter-based selections are supported, for example \"$request.header.Authorization\".",
+		"JWT":                "Represents the configuration of a JWT authorizer. Required for the JWT authorizer type. Supported only for HTTP APIs.",
+		"JWT.Audience":       "A list of the intended recipients of the JWT. A valid JWT must provide an aud that matches at least one entry in this list. See RFC 7519. Supported only for HTTP APIs.",
+		"JWT.Issuer":         "The base domain of the identity provider that issues JSON Web Tokens. For example, an Amazon Cognito user pool has the following format: https://cognito-idp.{region}.amazonaws.com/{userPoolId}\n               . Required for the JWT authorizer type. Supported only for HTTP APIs.",
+		"Name":               "The name of the authorizer.",
+		"ResultTTL":          "Authorizer caching is not currently supported. Don't specify this value for authorizers.",
+		"Type":               "The authorizer type. For WebSocket APIs, specify REQUEST for a Lambda function using incoming request parameters. For HTTP APIs, specify JWT to use JSON Web Tokens.",
+		"URI":                "The authorizer's Uniform Resource Identifier (URI). For REQUEST authorizers, this must be a well-formed Lambda function URI, for example, arn:aws:apigateway:us-west-2:lambda:path/2015-03-31/functions/arn:aws:lambda:us-west-2:{account_id}:function:{lambda_function_name}/invocations. In general, the URI has this form: arn:aws:apigateway:{region}:lambda:path/{service_api}\n               , where {region} is the same as the region hosting the Lambda function, path indicates that the remaining substring in the URI should be treated as the path to the resource, including the initial /. For Lambda functions, this is usually of the form /2015-03-31/functions/[FunctionARN]/invocations. Supported only for REQUEST authorizers.",
+	}
+}
+
+// Docs returns the documentation for Deployment and its fields.
+func (Deployment) Docs() map[string]string {
+	return map[string]string{
+		"":              "Deployment manages AmazonApiGatewayV2 Deployments.",
+		"API":           "The API identifier.",
+		"AutoDeployed":  "Specifies whether a deployment was automatically released.",
+		"Created":       "The date and time when the Deployment resource was created.",
+		"Description":   "The description for the deployment resource.",
+		"ID":            "The identifier for the deployment.",
+		"StageName":     "The name of the Stage resource for the Deployment resource to create.",
+		"Status":        "The status of the deployment: PENDING, FAILED, or SUCCEEDED.",
+		"StatusMessage": "May contain additional feedback on the status of an API deployment.",
+	}
+}
+
+// Docs returns the documentation for DomainName and its fields.
+func (DomainName) Docs() map[string]string {
+	return map[string]string{
+		"":                               "DomainName manages AmazonApiGatewayV2 DomainNames.",
+		"Config":                         "The domain name configurations.",
+		"Config.CertificateARN":          "An AWS-managed certificate that will be used by the edge-optimized endpoint for this domain name. AWS Certificate Manager is the only supported source.",
+		"Config.CertificateName":         "The user-friendly name of the certificate that will be used by the edge-optimized endpoint for this domain name.",
+		"Config.CertificateUploadDate":   "The timestamp when the certificate that was used by edge-optimized endpoint for this domain name was uploaded.",
+		"Config.DomainName":              "A domain name for the API.",
+		"Config.DomainNameStatus":        "The status of the domain name migration. The valid values are AVAILABLE and UPDATING. If the status is UPDATING, the domain cannot be modified further until the existing operation is complete. If it is AVAILABLE, the domain can be updated.",
+		"Config.DomainNameStatusMessage": "An optional text message containing detailed information about status of the domain name migration.",
+		"Config.EndpointType":            "The endpoint type.",
+		"Config.HostedZoneID":            "The Amazon Route 53 Hosted Zone ID of the endpoint.",
+		"Config.SecurityPolicy":          "The Transport Layer Security (TLS) version of the security policy for this domain name. The valid values are TLS_1_0 and TLS_1_2.",
+		"Mapping":                        "The API mapping selection expression.",
+		"Name":                           "The domain name.",
+		"Tags":                           "The collection of tags associated with a domain name.",
+	}
+}
+
+// Docs returns the documentation for Integration and its fields.
+func (Integration) Docs() map[string]string {
+	return map[string]string{
+		"":                                       "Integration manages AmazonApiGatewayV2 Integrations.",
+		"API":                                    "The API identifier.",
+		"APIGatewayManaged":                      "Specifies whether an integration is managed by API Gateway. If you created an API using using quick create, the resulting integration is managed by API Gateway. You can update a managed integration, but you can't delete it.",
+		"ConnectionID":                           "The ID of the VPC link for a private integration. Supported only for HTTP APIs.",
+		"ConnectionType":                         "The type of the network connection to the integration endpoint. Specify INTERNET for connections through the public routable internet or VPC_LINK for private connections between API Gateway and resources in a VPC. The default value is INTERNET.",
+		"ContentHandling":                        "Supported only for WebSocket APIs. Specifies how to handle response payload content type conversions. Supported values are CONVERT_TO_BINARY and CONVERT_TO_TEXT, with the following behaviors:CONVERT_TO_BINARY: Converts a response payload from a Base64-encoded string to the corresponding binary blob.CONVERT_TO_TEXT: Converts a response payload from a binary blob to a Base64-encoded string.If this property is not defined, the response payload will be passed through from the integration response to the route response or method response without modification.",
+		"CredentialsARN":                         "Specifies the credentials required for the integration, if any. For AWS integrations, three options are available. To specify an IAM Role for API Gateway to assume, use the role's Amazon Resource Name (ARN). To require that the caller's identity be passed through from the request, specify the string arn:aws:iam::*:user/*. To use resource-based permissions on supported AWS services, specify null.",
+		"Description":                            "The description of the integration.",
+		"ID":                                     "Represents the identifier of an integration.",
+		"IntegrationMethod":                      "Specifies the integration's HTTP method type.",
+		"IntegrationResponseSelectionExpression": "The integration response selection expression for the integration. Supported only for WebSocket APIs. See Integration Response Selection Expressions.",
+		"IntegrationType":                        "The integration type of an integration. One of the following:AWS: for integrating the route or method request with an AWS service action, including the Lambda function-invoking action. With the Lambda function-invoking action, this is referred to as the Lambda custom integration. With any other AWS service action, this is known as AWS integration. Supported only for WebSocket APIs.AWS_PROXY: for integrating the route or method request with the Lambda function-invoking action with the client request passed through as-is. This integration is also referred to as Lambda proxy integration.HTTP: for integrating the route or method request with an HTTP endpoint. This integration is also referred to as the HTTP custom integration. Supported only for WebSocket APIs.HTTP_PROXY: for integrating the route or method request with an HTTP endpoint, with the client request passed through as-is. This is also referred to as HTTP proxy integration. For HTTP API private integrations, use an HTTP_PROXY integration.MOCK: for integrating the route or method request with API Gateway as a \"loopback\" endpoint without invoking any backend. Supported only for WebSocket APIs.",
+		"IntegrationURI":                         "For a Lambda integration, specify the URI of a Lambda function.For an HTTP integration, specify a fully-qualified URL.For an HTTP API private integration, specify the ARN of an Application Load Balancer listener, Network Load Balancer listener, or AWS Cloud Map service. If you specify the ARN of an AWS Cloud Map service, API Gateway uses DiscoverInstances to identify resources. You can use query parameters to target specific resources. To learn more, see DiscoverInstances: https://docs.aws.amazon.com/cloud-map/latest/api/API_DiscoverInstances.html. For private integrations, all resources must be owned by the same AWS account.",
+		"PassthroughBehavior":                    "Specifies the pass-through behavior for incoming requests based on the Content-Type header in the request, and the available mapping templates specified as the requestTemplates property on the Integration resource. There are three valid values: WHEN_NO_MATCH, WHEN_NO_TEMPLATES, and NEVER. Supported only for WebSocket APIs.WHEN_NO_MATCH passes the request body for unmapped content types through to the integration backend without transformation.NEVER rejects unmapped content types with an HTTP 415 Unsupported Media Type response.WHEN_NO_TEMPLATES allows pass-through when the integration has no content types mapped to templates. However, if there is at least one content type defined, unmapped content types will be rejected with the same HTTP 415 Unsupported Media Type response.",
+		"PayloadFormatVersion":                   "Specifies the format of the payload sent to an integration. Required for HTTP APIs.",
+		"RequestParameters":                      "A key-value map specifying request parameters that are passed from the method request to the backend. The key is an integration request parameter name and the associated value is a method request parameter value or static value that must be enclosed within single quotes and pre-encoded as required by the backend. The method request parameter value must match the pattern of method.request.{location}.{name}\n               , where\n                  {location}\n                is querystring, path, or header; and\n                  {name}\n                must be a valid and unique method request parameter name. Supported only for WebSocket APIs.",
+		"RequestTemplates":                       "Represents a map of Velocity templates that are applied on the request payload based on the value of the Content-Type header sent by the client. The content type value is the key in this map, and the template (as a String) is the value. Supported only for WebSocket APIs.",
+		"TLS":                                    "The TLS configuration for a private integration. If you specify a TLS configuration, private integration traffic uses the HTTPS protocol. Supported only for HTTP APIs.",
+		"TLS.ServerName":                         "If you specify a server name, API Gateway uses it to verify the hostname on the integration's certificate. The server name is also included in the TLS handshake to support Server Name Indication (SNI) or virtual hosting.",
+		"TemplateSelectionExpression":            "The template selection expression for the integration.",
+		"Timeout":                                "Custom timeout between 50 and 29,000 milliseconds for WebSocket APIs and between 50 and 30,000 milliseconds for HTTP APIs. The default timeout is 29 seconds for WebSocket APIs and 30 seconds for HTTP APIs.",
+	}
+}
+
+// Docs returns the documentation for IntegrationResponse and its fields.
+func (IntegrationResponse) Docs() map[string]string {
+	return map[string]string{
+		"":                   "IntegrationResponse manages AmazonApiGatewayV2 IntegrationResponses.",
+		"API":                "The API identifier.",
+		"ContentHandling":    "Specifies how to handle response payload content type conversions. Supported values are CONVERT_TO_BINARY and CONVERT_TO_TEXT, with the following behaviors:CONVERT_TO_BINARY: Converts a response payload from a Base64-encoded string to the corresponding binary blob.CONVERT_TO_TEXT: Converts a response payload from a binary blob to a Base64-encoded string.If this property is not defined, the response payload will be passed through from the integration response to the route response or method response without modification.",
+		"ID":                 "The integration response ID.",
+		"Integration":        "The integration ID.",
+		"ResponseKey":        "The integration response key.",
+		"ResponseParameters": "A key-value map specifying response parameters that are passed to the method response from the backend. The key is a method response header parameter name and the mapped value is an integration response header value, a static value enclosed within a pair of single quotes, or a JSON expression from the integration response body. The mapping key must match the pattern of method.response.header.{name}, where {name} is a valid and unique header name. The mapped non-static value must match the pattern of integration.response.header.{name} or integration.response.body.{JSON-expression}, where {name} is a valid and unique response header name and {JSON-expression} is a valid JSON expression without the $ prefix.",
+		"ResponseTemplates":  "The collection of response templates for the integration response as a string-to-string map of key-value pairs. Response templates are represented as a key/value map, with a content-type as the key and a template as the value.",
+		"TemplateSelection":  "The template selection expression for the integration response. Supported only for WebSocket APIs.",
+	}
+}
+
+// Docs returns the documentation for Model and its fields.
+func (Model) Docs() map[string]string {
+	return map[string]string{
+		"":            "Model manages AmazonApiGatewayV2 Models.",
+		"API":         "The API identifier.",
+		"ContentType": "The content-type for the model, for example, \"application/json\".",
+		"Description": "The description of the model.",
+		"ID":          "The model identifier.",
+		"Name":        "The name of the model. Must be alphanumeric.",
+		"Schema":      "The schema for the model. For application/json models, this should be JSON schema draft 4 model.",
+	}
+}
+
+// Docs returns the documentation for Route and its fields.
+func (Route) Docs() map[string]string {
+	return map[string]string{
+		"":                                 "Route manages AmazonApiGatewayV2 Routes.",
+		"API":                              "The API identifier.",
+		"APIGatewayManaged":                "Specifies whether a route is managed by API Gateway. If you created an API using quick create, the $default route is managed by API Gateway. You can't modify the $default route key.",
+		"APIKeyRequired":                   "Specifies whether an API key is required for the route. Supported only for WebSocket APIs.",
+		"AuthorizationScopes":              "The authorization scopes supported by this route.",
+		"AuthorizationType":                "The authorization type for the route. For WebSocket APIs, valid values are NONE for open access, AWS_IAM for using AWS IAM permissions, and CUSTOM for using a Lambda authorizer For HTTP APIs, valid values are NONE for open access, or JWT for using JSON Web Tokens.",
+		"Authorizer":                       "The identifier of the Authorizer resource to be associated with this route. The authorizer identifier is generated by API Gateway when you created the authorizer.",
+		"ID":                               "The route ID.",
+		"Key":                              "The route key for the route.",
+		"ModelSelection":                   "The model selection expression for the route. Supported only for WebSocket APIs.",
+		"OperationName":                    "The operation name for the route.",
+		"RequestModels":                    "The request models for the route. Supported only for WebSocket APIs.",
+		"RequestParameters":                "The request parameters for the route. Supported only for WebSocket APIs.",
+		"RouteResponseSelectionExpression": "The route response selection expression for the route. Supported only for WebSocket APIs.",
+		"Target":                           "The target for the route.",
+	}
+}
+
+// Docs returns the documentation for RouteResponse and its fields.
+func (RouteResponse) Docs() map[string]string {
+	return map[string]string{
+		"":                         "RouteResponse manages AmazonApiGatewayV2 RouteResponses.",
+		"API":                      "The API identifier.",
+		"ID":                       "Represents the identifier of a route response.",
+		"Key":                      "The route response key.",
+		"ModelSelectionExpression": "The model selection expression for the route response. Supported only for WebSocket APIs.",
+		"ResponseModels":           "The response models for the route response.",
+		"ResponseParameters":       "The route response parameters.",
+		"Route":                    "The route ID.",
+	}
+}
+
+// Docs returns the documentation for Stage and its fields.
+func (Stage) Docs() map[string]string {
+	return map[string]string{
+		"":                                  "Stage manages AmazonApiGatewayV2 Stages.",
+		"API":                               "The API identifier.",
+		"APIGatewayManaged":                 "Specifies whether a stage is managed by API Gateway. If you created an API using quick create, the $default stage is managed by API Gateway. You can't modify the $default stage.",
+		"AccessLog":                         "Settings for logging access in this stage.",
+		"AccessLog.DestinationARN":          "The ARN of the CloudWatch Logs log group to receive access logs.",
+		"AccessLog.Format":                  "A single line format of the access logs of data, as specified by selected $context variables. The format must include at least $context.requestId.",
+		"AutoDeploy":                        "Specifies whether updates to an API automatically trigger a new deployment. The default value is false.",
+		"ClientCertificateID":               "The identifier of a client certificate for a Stage. Supported only for WebSocket APIs.",
+		"Created":                           "The timestamp when the stage was created.",
+		"DefaultRoute":                      "The default route settings for the stage.",
+		"DefaultRoute.LogLevel":             "Specifies the logging level for this route: INFO, ERROR, or OFF. This property affects the log entries pushed to Amazon CloudWatch Logs. Supported only for WebSocket APIs.",
+		"DefaultRoute.Metrics":              "Specifies whether detailed metrics are enabled.",
+		"DefaultRoute.ThrottlingBurstLimit": "Specifies the throttling burst limit.",
+		"DefaultRoute.ThrottlingRateLimit":  "Specifies the throttling rate limit.",
+		"DefaultRoute.Tracing":              "Specifies whether (true) or not (false) data trace logging is enabled for this route. This property affects the log entries pushed to Amazon CloudWatch Logs. Supported only for WebSocket APIs.",
+		"Deployment":                        "The deployment identifier of the API stage.",
+		"Description":                       "The description for the API stage.",
+		"LastDeploymentStatusMessage":       "Describes the status of the last deployment of a stage. Supported only for stages with autoDeploy enabled.",
+		"Name":                              "The name of the stage.",
+		"RouteSettings":                     "Route settings for the stage, by routeKey.",
+		"Tags":                              "The collection of tags. Each tag element is associated with a given resource.",
+		"Updated":                           "The timestamp when the stage was last updated.",
+		"Variables":                         "A map that defines the stage variables for a Stage. Variable names can have alphanumeric and underscore characters, and the values must match [A-Za-z0-9-._~:/?#&=,]+.",
+	}
+}
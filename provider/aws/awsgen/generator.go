@@ -24,6 +24,7 @@ type Generator struct {
 func (g *Generator) GeneratePkgDoc(w io.Writer) error {
 	fmt.Fprintf(w, "// Package %s provides resources for %s.\n", g.Package, g.Service.Metadata.ServiceFullName)
 	fmt.Fprintf(w, "package %s\n", g.Package)
+	fmt.Fprintf(w, "\n//go:generate go run ../docgen\n")
 	return nil
 }
 
@@ -0,0 +1,179 @@
+// Command docgen generates documentation lookups for resources from the
+// comments in the generated resource types.
+//
+// The documentation is written to docs.go in the current directory, with a
+// Docs() method for every type that has a CloudFormationType() method.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+)
+
+const output = "docs.go"
+
+func main() {
+	if err := run("."); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(dir string) error {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		name := info.Name()
+		return name != output && !strings.HasSuffix(name, "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	if len(pkgs) != 1 {
+		return fmt.Errorf("expected one package, got %d", len(pkgs))
+	}
+	var pkg *ast.Package
+	for _, p := range pkgs {
+		pkg = p
+	}
+
+	resources := make(map[string]bool)
+	types := make(map[string]*ast.TypeSpec)
+	typeDocs := make(map[string]*ast.CommentGroup)
+	for _, f := range pkg.Files {
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Name.Name == "CloudFormationType" && d.Recv != nil {
+					resources[receiverName(d.Recv.List[0].Type)] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					types[ts.Name.Name] = ts
+					doc := ts.Doc
+					if doc == nil {
+						doc = d.Doc
+					}
+					typeDocs[ts.Name.Name] = doc
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		if _, ok := types[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by docgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n", pkg.Name)
+	for _, name := range names {
+		st, ok := types[name].Type.(*ast.StructType)
+		if !ok {
+			continue
+		}
+		docs := map[string]string{"": text(typeDocs[name])}
+		fieldDocs(st, "", docs)
+
+		keys := make([]string, 0, len(docs))
+		for k := range docs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		fmt.Fprintf(&buf, "\n// Docs returns the documentation for %s and its fields.\n", name)
+		fmt.Fprintf(&buf, "func (%s) Docs() map[string]string {\n", name)
+		fmt.Fprintf(&buf, "\treturn map[string]string{\n")
+		for _, k := range keys {
+			if docs[k] == "" {
+				continue
+			}
+			fmt.Fprintf(&buf, "\t\t%q: %q,\n", k, docs[k])
+		}
+		fmt.Fprintf(&buf, "\t}\n}\n")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(output, src, 0644)
+}
+
+// fieldDocs collects the documentation of all fields in the struct, including
+// nested structs. The fields are keyed by their path.
+func fieldDocs(st *ast.StructType, prefix string, docs map[string]string) {
+	for _, field := range st.Fields.List {
+		for _, n := range field.Names {
+			path := prefix + n.Name
+			docs[path] = text(field.Doc)
+			if nested := structType(field.Type); nested != nil {
+				fieldDocs(nested, path+".", docs)
+			}
+		}
+	}
+}
+
+// structType returns the anonymous struct type of a field, if the field is a
+// struct, pointer to a struct or a slice of structs.
+func structType(expr ast.Expr) *ast.StructType {
+	switch t := expr.(type) {
+	case *ast.StructType:
+		return t
+	case *ast.StarExpr:
+		return structType(t.X)
+	case *ast.ArrayType:
+		return structType(t.Elt)
+	}
+	return nil
+}
+
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	}
+	return ""
+}
+
+// text returns the text of a comment. Lines within a paragraph are joined,
+// indented lines are kept as is.
+func text(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(doc.Text()), "\n")
+	var out strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			prev := lines[i-1]
+			switch {
+			case line == "" || prev == "":
+				out.WriteString("\n")
+			case strings.HasPrefix(line, " ") || strings.HasPrefix(prev, " "):
+				out.WriteString("\n")
+			default:
+				out.WriteString(" ")
+			}
+		}
+		out.WriteString(line)
+	}
+	return out.String()
+}
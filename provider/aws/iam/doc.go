@@ -1,2 +1,4 @@
 // Package iam provides resources for AWS Identity and Access Management.
 package iam
+
+//go:generate go run ../docgen
@@ -0,0 +1,156 @@
+// Code generated by docgen. DO NOT EDIT.
+
+package iam
+
+// Docs returns the documentation for AccessKey and its fields.
+func (AccessKey) Docs() map[string]string {
+	return map[string]string{
+		"":                          "AccessKey manages AWS Identity and Access Management AccessKeys.",
+		"AccessKey":                 "A structure with details about the access key.",
+		"AccessKey.AccessKeyID":     "The ID for this access key.",
+		"AccessKey.CreateDate":      "The date when the access key was created.",
+		"AccessKey.SecretAccessKey": "The secret key used to sign requests.",
+		"AccessKey.Status":          "The status of the access key. Active means that the key is valid for API calls, while Inactive means it is not.",
+		"AccessKey.UserName":        "The name of the IAM user that the access key is associated with.",
+		"UserName":                  "The name of the IAM user that the new key will belong to.This parameter allows (through its regex pattern) a string of characters consisting of upper and lowercase alphanumeric characters with no spaces. You can also include any of the following characters: _+=,.@-",
+	}
+}
+
+// Docs returns the documentation for Group and its fields.
+func (Group) Docs() map[string]string {
+	return map[string]string{
+		"":        "Group manages AWS Identity and Access Management Groups.",
+		"ARN":     "The Amazon Resource Name (ARN) specifying the group. For more information about ARNs and how to use them in policies, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"Created": "The date and time, in ISO 8601 date-time format, when the group was created.",
+		"ID":      "The stable and unique string identifying the group. For more information about IDs, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"Name":    "The name of the group to create. Do not include the path in this value.IAM user, group, role, and policy names must be unique within the account. Names are not distinguished by case. For example, you cannot create resources named both \"MyResource\" and \"myresource\".",
+		"Path":    "The path to the group. For more information about paths, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.This parameter is optional. If it is not included, it defaults to a slash (/).This parameter allows (through its regex pattern) a string of characters consisting of either a forward slash (/) by itself or a string that must begin and end with forward slashes. In addition, it can contain any ASCII character from the ! (\\u0021) through the DEL character (\\u007F), including most punctuation characters, digits, and upper and lowercased letters.",
+	}
+}
+
+// Docs returns the documentation for InstanceProfile and its fields.
+func (InstanceProfile) Docs() map[string]string {
+	return map[string]string{
+		"":                                               "InstanceProfile manages AWS Identity and Access Management InstanceProfiles.",
+		"InstanceProfile":                                "A structure containing details about the new instance profile.",
+		"InstanceProfile.ARN":                            "The Amazon Resource Name (ARN) specifying the instance profile. For more information about ARNs and how to use them in policies, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"InstanceProfile.CreateDate":                     "The date when the instance profile was created.",
+		"InstanceProfile.InstanceProfileID":              "The stable and unique string identifying the instance profile. For more information about IDs, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"InstanceProfile.InstanceProfileName":            "The name identifying the instance profile.",
+		"InstanceProfile.Path":                           "The path to the instance profile. For more information about paths, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"InstanceProfile.Roles":                          "The role associated with the instance profile.",
+		"InstanceProfile.Roles.ARN":                      "The Amazon Resource Name (ARN) specifying the role. For more information about ARNs and how to use them in policies, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide guide.",
+		"InstanceProfile.Roles.AssumeRolePolicyDocument": "The policy that grants an entity permission to assume the role.",
+		"InstanceProfile.Roles.CreateDate":               "The date and time, in ISO 8601 date-time format, when the role was created.",
+		"InstanceProfile.Roles.Description":              "A description of the role that you provide.",
+		"InstanceProfile.Roles.MaxSessionDuration":       "The maximum session duration (in seconds) for the specified role. Anyone who uses the AWS CLI, or API to assume the role can specify the duration using the optional DurationSeconds API parameter or duration-seconds CLI parameter.",
+		"InstanceProfile.Roles.Path":                     "The path to the role. For more information about paths, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"InstanceProfile.Roles.PermissionsBoundary":      "The ARN of the policy used to set the permissions boundary for the role.For more information about permissions boundaries, see Permissions Boundaries for IAM Identities : https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies_boundaries.html in the IAM User Guide.",
+		"InstanceProfile.Roles.PermissionsBoundary.PermissionsBoundaryARN":  "The ARN of the policy used to set the permissions boundary for the user or role.",
+		"InstanceProfile.Roles.PermissionsBoundary.PermissionsBoundaryType": "The permissions boundary usage type that indicates what type of IAM resource is used as the permissions boundary for an entity. This data type can only have a value of Policy.",
+		"InstanceProfile.Roles.RoleID":                                      "The stable and unique string identifying the role. For more information about IDs, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"InstanceProfile.Roles.RoleLastUsed":                                "Contains information about the last time that an IAM role was used. This includes the date and time and the Region in which the role was last used. Activity is only reported for the trailing 400 days. This period can be shorter if your Region began supporting these features within the last year. The role might have been used more than 400 days ago. For more information, see Regions Where Data Is Tracked: https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies_access-advisor.html#access-advisor_tracking-period in the IAM User Guide.",
+		"InstanceProfile.Roles.RoleLastUsed.LastUsedDate":                   "The date and time, in\u00a0ISO 8601 date-time format that the role was last used.This field is null if the role has not been used within the IAM tracking period. For more information about the tracking period, see Regions Where Data Is Tracked: https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies_access-advisor.html#access-advisor_tracking-period in the IAM User Guide.",
+		"InstanceProfile.Roles.RoleLastUsed.Region":                         "The name of the AWS Region in which the role was last used.",
+		"InstanceProfile.Roles.RoleName":                                    "The friendly name that identifies the role.",
+		"InstanceProfile.Roles.Tags":                                        "A list of tags that are attached to the specified role. For more information about tagging, see Tagging IAM Identities: https://docs.aws.amazon.com/IAM/latest/UserGuide/id_tags.html in the IAM User Guide.",
+		"InstanceProfile.Roles.Tags.Key":                                    "The key name that can be used to look up or retrieve the associated value. For example, Department or Cost Center are common choices.",
+		"InstanceProfile.Roles.Tags.Value":                                  "The value associated with this tag. For example, tags with a key name of Department could have values such as Human Resources, Accounting, and Support. Tags with a key name of Cost Center might have values that consist of the number associated with the different cost centers in your company. Typically, many resources have tags with the same key name but with different values.AWS always interprets the tag Value as a single string. If you need to store an array, you can store comma-separated values in the string. However, you must interpret the value in your code.",
+		"Name":                                                              "The name of the instance profile to create.This parameter allows (through its regex pattern) a string of characters consisting of upper and lowercase alphanumeric characters with no spaces. You can also include any of the following characters: _+=,.@-",
+		"Path":                                                              "The path to the instance profile. For more information about paths, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.This parameter is optional. If it is not included, it defaults to a slash (/).This parameter allows (through its regex pattern) a string of characters consisting of either a forward slash (/) by itself or a string that must begin and end with forward slashes. In addition, it can contain any ASCII character from the ! (\\u0021) through the DEL character (\\u007F), including most punctuation characters, digits, and upper and lowercased letters.",
+	}
+}
+
+// Docs returns the documentation for Policy and its fields.
+func (Policy) Docs() map[string]string {
+	return map[string]string{
+		"":                              "Policy manages AWS Identity and Access Management Policies.",
+		"AttachmentCount":               "The number of entities (users, groups, and roles) that the policy is attached to.",
+		"CreateDate":                    "The date and time, in ISO 8601 date-time format, when the policy was created.",
+		"DefaultVersionID":              "The identifier for the version of the policy that is set as the default version.",
+		"Description":                   "A friendly description of the policy.Typically used to store information about the permissions defined in the policy. For example, \"Grants access to production DynamoDB tables.\"The policy description is immutable. After a value is assigned, it cannot be changed.",
+		"Document":                      "The JSON policy document that you want to use as the content for the new policy.You must provide policies in JSON format in IAM. However, for AWS CloudFormation templates formatted in YAML, you can provide the policy in JSON or YAML format. AWS CloudFormation always converts a YAML policy to JSON format before submitting it to IAM.The regex pattern used to validate this parameter is a string of characters consisting of the following:  Any printable ASCII character ranging from the space character (\\u0020) through the end of the ASCII character range\n  The printable characters in the Basic Latin and Latin-1 Supplement character set (through \\u00FF)\n  The special characters tab (\\u0009), line feed (\\u000A), and carriage return (\\u000D)",
+		"IsAttachable":                  "Specifies whether the policy can be attached to an IAM user, group, or role.",
+		"Name":                          "The friendly name of the policy.IAM user, group, role, and policy names must be unique within the account. Names are not distinguished by case. For example, you cannot create resources named both \"MyResource\" and \"myresource\".",
+		"Path":                          "The path for the policy.For more information about paths, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.This parameter is optional. If it is not included, it defaults to a slash (/).This parameter allows (through its regex pattern) a string of characters consisting of either a forward slash (/) by itself or a string that must begin and end with forward slashes. In addition, it can contain any ASCII character from the ! (\\u0021) through the DEL character (\\u007F), including most punctuation characters, digits, and upper and lowercased letters.",
+		"PermissionsBoundaryUsageCount": "The number of entities (users and roles) for which the policy is used to set the permissions boundary. For more information about permissions boundaries, see Permissions Boundaries for IAM Identities : https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies_boundaries.html in the IAM User Guide.",
+		"PolicyID":                      "The stable and unique string identifying the policy.For more information about IDs, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"UpdateDate":                    "The date and time, in ISO 8601 date-time format, when the policy was last updated.When a policy has only one version, this field contains the date and time when the policy was created. When a policy has more than one version, this field contains the date and time when the most recent policy version was created.",
+	}
+}
+
+// Docs returns the documentation for Role and its fields.
+func (Role) Docs() map[string]string {
+	return map[string]string{
+		"":                          "Role manages AWS Identity and Access Management Roles.",
+		"ARN":                       "The Amazon Resource Name (ARN) specifying the role. For more information about ARNs and how to use them in policies, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide guide.",
+		"AssumeRolePolicy":          "The trust relationship policy document that grants an entity permission to assume the role.In IAM, you must provide a JSON policy that has been converted to a string. However, for AWS CloudFormation templates formatted in YAML, you can provide the policy in JSON or YAML format. AWS CloudFormation always converts a YAML policy to JSON format before submitting it to IAM.The regex pattern used to validate this parameter is a string of characters consisting of the following:  Any printable ASCII character ranging from the space character (\\u0020) through the end of the ASCII character range\n  The printable characters in the Basic Latin and Latin-1 Supplement character set (through \\u00FF)\n  The special characters tab (\\u0009), line feed (\\u000A), and carriage return (\\u000D)\n\n Upon success, the response includes the same trust policy in JSON format.",
+		"CreateDate":                "The date and time, in ISO 8601 date-time format, when the role was created.",
+		"Description":               "A description of the role.",
+		"MaxSessionDuration":        "The maximum session duration (in seconds) that you want to set for the specified role. If you do not specify a value for this setting, the default maximum of one hour is applied. This setting can have a value from 1 hour to 12 hours.Anyone who assumes the role from the AWS CLI or API can use the DurationSeconds API parameter or the duration-seconds CLI parameter to request a longer session. The MaxSessionDuration setting determines the maximum duration that can be requested using the DurationSeconds parameter. If users don't specify a value for the DurationSeconds parameter, their security credentials are valid for one hour by default. This applies when you use the AssumeRole* API operations or the assume-role* CLI operations but does not apply when you use those operations to create a console URL. For more information, see Using IAM Roles: https://docs.aws.amazon.com/IAM/latest/UserGuide/id_roles_use.html in the IAM User Guide.",
+		"Name":                      "The name of the role to create.IAM user, group, role, and policy names must be unique within the account. Names are not distinguished by case. For example, you cannot create resources named both \"MyResource\" and \"myresource\".",
+		"Path":                      "The path to the role. For more information about paths, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.This parameter is optional. If it is not included, it defaults to a slash (/).This parameter allows (through its regex pattern) a string of characters consisting of either a forward slash (/) by itself or a string that must begin and end with forward slashes. In addition, it can contain any ASCII character from the ! (\\u0021) through the DEL character (\\u007F), including most punctuation characters, digits, and upper and lowercased letters.",
+		"PermissionsBoundary":       "The ARN of the policy that is used to set the permissions boundary for the role.",
+		"RoleID":                    "The stable and unique string identifying the role. For more information about IDs, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"RoleLastUsed":              "Contains information about the last time that an IAM role was used. This includes the date and time and the Region in which the role was last used. Activity is only reported for the trailing 400 days. This period can be shorter if your Region began supporting these features within the last year. The role might have been used more than 400 days ago. For more information, see Regions Where Data Is Tracked: https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies_access-advisor.html#access-advisor_tracking-period in the IAM User Guide.",
+		"RoleLastUsed.LastUsedDate": "The date and time, in\u00a0ISO 8601 date-time format that the role was last used.This field is null if the role has not been used within the IAM tracking period. For more information about the tracking period, see Regions Where Data Is Tracked: https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies_access-advisor.html#access-advisor_tracking-period in the IAM User Guide.",
+		"RoleLastUsed.Region":       "The name of the AWS Region in which the role was last used.",
+		"Tags":                      "A list of tags that you want to attach to the newly created role. Each tag consists of a key name and an associated value. For more information about tagging, see Tagging IAM Identities: https://docs.aws.amazon.com/IAM/latest/UserGuide/id_tags.html in the IAM User Guide.If any one of the tags is invalid or if you exceed the allowed number of tags per role, then the entire request fails and the role is not created.",
+		"Tags.Key":                  "The key name that can be used to look up or retrieve the associated value. For example, Department or Cost Center are common choices.",
+		"Tags.Value":                "The value associated with this tag. For example, tags with a key name of Department could have values such as Human Resources, Accounting, and Support. Tags with a key name of Cost Center might have values that consist of the number associated with the different cost centers in your company. Typically, many resources have tags with the same key name but with different values.AWS always interprets the tag Value as a single string. If you need to store an array, you can store comma-separated values in the string. However, you must interpret the value in your code.",
+	}
+}
+
+// Docs returns the documentation for ServiceLinkedRole and its fields.
+func (ServiceLinkedRole) Docs() map[string]string {
+	return map[string]string{
+		"":                              "ServiceLinkedRole manages AWS Identity and Access Management ServiceLinkedRoles.",
+		"AWSServiceName":                "The service principal for the AWS service to which this role is attached. You use a string similar to a URL but without the http:// in front. For example: elasticbeanstalk.amazonaws.com. Service principals are unique and case-sensitive. To find the exact service principal for your service-linked role, see AWS Services That Work with IAM: https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_aws-services-that-work-with-iam.html in the IAM User Guide. Look for the services that have Yes in the Service-Linked Role column. Choose the Yes link to view the service-linked role documentation for that service.",
+		"CustomSuffix":                  "A string that you provide, which is combined with the service-provided prefix to form the complete role name. If you make multiple requests for the same service, then you must supply a different CustomSuffix for each request. Otherwise the request fails with a duplicate role name error. For example, you could add -1 or -debug to the suffix.Some services do not support the CustomSuffix parameter. If you provide an optional suffix and the operation fails, try the operation again without the suffix.",
+		"Description":                   "The description of the role.",
+		"Role":                          "A Role object that contains details about the newly created role.",
+		"Role.ARN":                      "The Amazon Resource Name (ARN) specifying the role. For more information about ARNs and how to use them in policies, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide guide.",
+		"Role.AssumeRolePolicyDocument": "The policy that grants an entity permission to assume the role.",
+		"Role.CreateDate":               "The date and time, in ISO 8601 date-time format, when the role was created.",
+		"Role.Description":              "A description of the role that you provide.",
+		"Role.MaxSessionDuration":       "The maximum session duration (in seconds) for the specified role. Anyone who uses the AWS CLI, or API to assume the role can specify the duration using the optional DurationSeconds API parameter or duration-seconds CLI parameter.",
+		"Role.Path":                     "The path to the role. For more information about paths, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"Role.PermissionsBoundary":      "The ARN of the policy used to set the permissions boundary for the role.For more information about permissions boundaries, see Permissions Boundaries for IAM Identities : https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies_boundaries.html in the IAM User Guide.",
+		"Role.PermissionsBoundary.PermissionsBoundaryARN":  "The ARN of the policy used to set the permissions boundary for the user or role.",
+		"Role.PermissionsBoundary.PermissionsBoundaryType": "The permissions boundary usage type that indicates what type of IAM resource is used as the permissions boundary for an entity. This data type can only have a value of Policy.",
+		"Role.RoleID":                    "The stable and unique string identifying the role. For more information about IDs, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"Role.RoleLastUsed":              "Contains information about the last time that an IAM role was used. This includes the date and time and the Region in which the role was last used. Activity is only reported for the trailing 400 days. This period can be shorter if your Region began supporting these features within the last year. The role might have been used more than 400 days ago. For more information, see Regions Where Data Is Tracked: https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies_access-advisor.html#access-advisor_tracking-period in the IAM User Guide.",
+		"Role.RoleLastUsed.LastUsedDate": "The date and time, in\u00a0ISO 8601 date-time format that the role was last used.This field is null if the role has not been used within the IAM tracking period. For more information about the tracking period, see Regions Where Data Is Tracked: https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies_access-advisor.html#access-advisor_tracking-period in the IAM User Guide.",
+		"Role.RoleLastUsed.Region":       "The name of the AWS Region in which the role was last used.",
+		"Role.RoleName":                  "The friendly name that identifies the role.",
+		"Role.Tags":                      "A list of tags that are attached to the specified role. For more information about tagging, see Tagging IAM Identities: https://docs.aws.amazon.com/IAM/latest/UserGuide/id_tags.html in the IAM User Guide.",
+		"Role.Tags.Key":                  "The key name that can be used to look up or retrieve the associated value. For example, Department or Cost Center are common choices.",
+		"Role.Tags.Value":                "The value associated with this tag. For example, tags with a key name of Department could have values such as Human Resources, Accounting, and Support. Tags with a key name of Cost Center might have values that consist of the number associated with the different cost centers in your company. Typically, many resources have tags with the same key name but with different values.AWS always interprets the tag Value as a single string. If you need to store an array, you can store comma-separated values in the string. However, you must interpret the value in your code.",
+	}
+}
+
+// Docs returns the documentation for User and its fields.
+func (User) Docs() map[string]string {
+	return map[string]string{
+		"":                         "User manages AWS Identity and Access Management Users.",
+		"Path":                     "The path for the user name. For more information about paths, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.This parameter is optional. If it is not included, it defaults to a slash (/).This parameter allows (through its regex pattern) a string of characters consisting of either a forward slash (/) by itself or a string that must begin and end with forward slashes. In addition, it can contain any ASCII character from the ! (\\u0021) through the DEL character (\\u007F), including most punctuation characters, digits, and upper and lowercased letters.",
+		"PermissionsBoundary":      "The ARN of the policy that is used to set the permissions boundary for the user.",
+		"Tags":                     "A list of tags that you want to attach to the newly created user. Each tag consists of a key name and an associated value. For more information about tagging, see Tagging IAM Identities: https://docs.aws.amazon.com/IAM/latest/UserGuide/id_tags.html in the IAM User Guide.If any one of the tags is invalid or if you exceed the allowed number of tags per user, then the entire request fails and the user is not created.",
+		"Tags.Key":                 "The key name that can be used to look up or retrieve the associated value. For example, Department or Cost Center are common choices.",
+		"Tags.Value":               "The value associated with this tag. For example, tags with a key name of Department could have values such as Human Resources, Accounting, and Support. Tags with a key name of Cost Center might have values that consist of the number associated with the different cost centers in your company. Typically, many resources have tags with the same key name but with different values.AWS always interprets the tag Value as a single string. If you need to store an array, you can store comma-separated values in the string. However, you must interpret the value in your code.",
+		"User":                     "A structure with details about the new IAM user.",
+		"User.ARN":                 "The Amazon Resource Name (ARN) that identifies the user. For more information about ARNs and how to use ARNs in policies, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"User.CreateDate":          "The date and time, in ISO 8601 date-time format, when the user was created.",
+		"User.PasswordLastUsed":    "The date and time, in ISO 8601 date-time format, when the user's password was last used to sign in to an AWS website. For a list of AWS websites that capture a user's last sign-in time, see the Credential Reports topic in the IAM User Guide. If a password is used more than once in a five-minute span, only the first use is returned in this field. If the field is null (no value), then it indicates that they never signed in with a password. This can be because:  The user never had a password.\n  A password exists but has not been used since IAM started tracking this information on October 20, 2014.\n\nA null value does not mean that the user never had a password. Also, if the user does not currently have a password but had one in the past, then this field contains the date and time the most recent password was used.This value is returned only in the GetUser and ListUsers operations.",
+		"User.Path":                "The path to the user. For more information about paths, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"User.PermissionsBoundary": "The ARN of the policy used to set the permissions boundary for the user.For more information about permissions boundaries, see Permissions Boundaries for IAM Identities : https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies_boundaries.html in the IAM User Guide.",
+		"User.PermissionsBoundary.PermissionsBoundaryARN":  "The ARN of the policy used to set the permissions boundary for the user or role.",
+		"User.PermissionsBoundary.PermissionsBoundaryType": "The permissions boundary usage type that indicates what type of IAM resource is used as the permissions boundary for an entity. This data type can only have a value of Policy.",
+		"User.Tags":       "A list of tags that are associated with the specified user. For more information about tagging, see Tagging IAM Identities: https://docs.aws.amazon.com/IAM/latest/UserGuide/id_tags.html in the IAM User Guide.",
+		"User.Tags.Key":   "The key name that can be used to look up or retrieve the associated value. For example, Department or Cost Center are common choices.",
+		"User.Tags.Value": "The value associated with this tag. For example, tags with a key name of Department could have values such as Human Resources, Accounting, and Support. Tags with a key name of Cost Center might have values that consist of the number associated with the different cost centers in your company. Typically, many resources have tags with the same key name but with different values.AWS always interprets the tag Value as a single string. If you need to store an array, you can store comma-separated values in the string. However, you must interpret the value in your code.",
+		"User.UserID":     "The stable and unique string identifying the user. For more information about IDs, see IAM Identifiers: https://docs.aws.amazon.com/IAM/latest/UserGuide/Using_Identifiers.html in the IAM User Guide.",
+		"User.UserName":   "The friendly name identifying the user.",
+		"UserName":        "The name of the user to create.IAM user, group, role, and policy names must be unique within the account. Names are not distinguished by case. For example, you cannot create resources named both \"MyResource\" and \"myresource\".",
+	}
+}
@@ -1,2 +1,4 @@
 // Package lambda provides resources for AWS Lambda.
 package lambda
+
+//go:generate go run ../docgen
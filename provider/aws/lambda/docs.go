@@ -0,0 +1,124 @@
+// Code generated by docgen. DO NOT EDIT.
+
+package lambda
+
+// Docs returns the documentation for Alias and its fields.
+func (Alias) Docs() map[string]string {
+	return map[string]string{
+		"":                                       "Alias manages AWS Lambda Aliases.",
+		"ARN":                                    "The Amazon Resource Name (ARN) of the alias.",
+		"Description":                            "A description of the alias.",
+		"FunctionName":                           "The name of the Lambda function.\n\nName formats\n\n  Function name - MyFunction.\n  Function ARN - arn:aws:lambda:us-west-2:123456789012:function:MyFunction.\n  Partial ARN - 123456789012:function:MyFunction.\n\nThe length constraint applies only to the full ARN. If you specify only the function name, it is limited to 64 characters in length.",
+		"FunctionVersion":                        "The function version that the alias invokes.",
+		"Name":                                   "The name of the alias.",
+		"RevisionID":                             "A unique identifier that changes when you update the alias.",
+		"RoutingConfig":                          "The routing configuration of the alias.",
+		"RoutingConfig.AdditionalVersionWeights": "The name of the second alias, and the percentage of traffic that's routed to it.",
+	}
+}
+
+// Docs returns the documentation for EventSourceMapping and its fields.
+func (EventSourceMapping) Docs() map[string]string {
+	return map[string]string{
+		"":                            "EventSourceMapping manages AWS Lambda EventSourceMappings.",
+		"BatchSize":                   "The maximum number of items to retrieve in a single batch.  Amazon Kinesis - Default 100. Max 10,000.\n  Amazon DynamoDB Streams - Default 100. Max 1,000.\n  Amazon Simple Queue Service - Default 10. Max 10.",
+		"BisectBatchOnFunctionError":  "(Streams) If the function returns an error, split the batch in two and retry.",
+		"DestinationConfig":           "(Streams) An Amazon SQS queue or Amazon SNS topic destination for discarded records.",
+		"DestinationConfig.OnFailure": "The destination configuration for failed invocations.",
+		"DestinationConfig.OnFailure.Destination": "The Amazon Resource Name (ARN) of the destination resource.",
+		"DestinationConfig.OnSuccess":             "The destination configuration for successful invocations.",
+		"DestinationConfig.OnSuccess.Destination": "The Amazon Resource Name (ARN) of the destination resource.",
+		"Enabled":                   "Disables the event source mapping to pause polling and invocation.",
+		"EventSource":               "The Amazon Resource Name (ARN) of the event source.  Amazon Kinesis - The ARN of the data stream or a stream consumer.\n  Amazon DynamoDB Streams - The ARN of the stream.\n  Amazon Simple Queue Service - The ARN of the queue.",
+		"Function":                  "The name of the Lambda function.\n\nName formats\n\n  Function name - MyFunction.\n  Function ARN - arn:aws:lambda:us-west-2:123456789012:function:MyFunction.\n  Version or Alias ARN - arn:aws:lambda:us-west-2:123456789012:function:MyFunction:PROD.\n  Partial ARN - 123456789012:function:MyFunction.\n\nThe length constraint applies only to the full ARN. If you specify only the function name, it's limited to 64 characters in length.",
+		"FunctionARN":               "The ARN of the Lambda function.",
+		"LastModified":              "The date that the event source mapping was last updated, or its state changed.",
+		"LastProcessingResult":      "The result of the last AWS Lambda invocation of your Lambda function.",
+		"MaxBatchWindow":            "(Streams) The maximum amount of time to gather records before invoking the function, in seconds.",
+		"MaxRecordAge":              "(Streams) The maximum age of a record that Lambda sends to a function for processing.",
+		"MaxRetries":                "(Streams) The maximum number of times to retry when the function returns an error.",
+		"ParallelizationFactor":     "(Streams) The number of batches to process from each shard concurrently.",
+		"StartingPosition":          "The position in a stream from which to start reading. Required for Amazon Kinesis and Amazon DynamoDB Streams sources. AT_TIMESTAMP is only supported for Amazon Kinesis streams.",
+		"StartingPositionTimestamp": "With StartingPosition set to AT_TIMESTAMP, the time from which to start reading.",
+		"State":                     "The state of the event source mapping. It can be one of the following: Creating, Enabling, Enabled, Disabling, Disabled, Updating, or Deleting.",
+		"StateTransitionReason":     "Indicates whether the last change to the event source mapping was made by a user, or by the Lambda service.",
+		"UUID":                      "The identifier of the event source mapping.",
+	}
+}
+
+// Docs returns the documentation for Function and its fields.
+func (Function) Docs() map[string]string {
+	return map[string]string{
+		"":                           "Function manages AWS Lambda Functions.",
+		"ARN":                        "The function's Amazon Resource Name (ARN).",
+		"Code":                       "The code for the function.",
+		"Code.S3Bucket":              "An Amazon S3 bucket in the same AWS Region as your function. The bucket can be in a different AWS account.",
+		"Code.S3Key":                 "The Amazon S3 key of the deployment package.",
+		"Code.S3ObjectVersion":       "For versioned objects, the version of the deployment package object to use.",
+		"Code.ZipFile":               "The base64-encoded contents of the deployment package. AWS SDK and AWS CLI clients handle the encoding for you.",
+		"CodeSha256":                 "The SHA256 hash of the function's deployment package.",
+		"CodeSize":                   "The size of the function's deployment package, in bytes.",
+		"DeadLetter":                 "A dead letter queue configuration that specifies the queue or topic where Lambda sends asynchronous events when they fail processing. For more information, see Dead Letter Queues: https://docs.aws.amazon.com/lambda/latest/dg/invocation-async.html#dlq.",
+		"DeadLetter.ARN":             "The Amazon Resource Name (ARN) of an Amazon SQS queue or Amazon SNS topic.",
+		"Description":                "A description of the function.",
+		"Environment":                "Environment variables that are accessible from function code during execution.",
+		"Environment.Variables":      "Environment variable key-value pairs.",
+		"Handler":                    "The name of the method within your code that Lambda calls to execute your function. The format includes the file name. It can also include namespaces and other qualifiers, depending on the runtime. For more information, see Programming Model: https://docs.aws.amazon.com/lambda/latest/dg/programming-model-v2.html.",
+		"KMSKeyARN":                  "The ARN of the AWS Key Management Service (AWS KMS) key that's used to encrypt your function's environment variables. If it's not provided, AWS Lambda uses a default service key.",
+		"LastModified":               "The date and time that the function was last updated, in ISO-8601 format (YYYY-MM-DDThh:mm:ss.sTZD).",
+		"LastUpdateStatus":           "The status of the last update that was performed on the function. This is first set to Successful after function creation completes.",
+		"LastUpdateStatusReason":     "The reason for the last update that was performed on the function.",
+		"LastUpdateStatusReasonCode": "The reason code for the last update that was performed on the function.",
+		"Layers":                     "A list of function layers to add to the function's execution environment. Specify each layer by its ARN, including the version.",
+		"MasterARN":                  "For Lambda@Edge functions, the ARN of the master function.",
+		"MemorySize":                 "The amount of memory that your function has access to. Increasing the function's memory also increases its CPU allocation. The default value is 128 MB. The value must be a multiple of 64 MB.",
+		"Name":                       "The name of the Lambda function.\n\nName formats\n\n  Function name - my-function.\n  Function ARN - arn:aws:lambda:us-west-2:123456789012:function:my-function.\n  Partial ARN - 123456789012:function:my-function.\n\nThe length constraint applies only to the full ARN. If you specify only the function name, it is limited to 64 characters in length.",
+		"Publish":                    "Set to true to publish the first version of the function during creation.",
+		"RevisionID":                 "The latest updated revision of the function or alias.",
+		"Role":                       "The Amazon Resource Name (ARN) of the function's execution role.",
+		"Runtime":                    "The identifier of the function's runtime.",
+		"State":                      "The current state of the function. When the state is Inactive, you can reactivate the function by invoking it.",
+		"StateReason":                "The reason for the function's current state.",
+		"StateReasonCode":            "The reason code for the function's current state. When the code is Creating, you can't invoke or modify the function.",
+		"Tags":                       "A list of tags to apply to the function.",
+		"Timeout":                    "The amount of time that Lambda allows a function to run before stopping it. The default is 3 seconds. The maximum allowed value is 900 seconds.",
+		"Tracing":                    "Set Mode to Active to sample and trace a subset of incoming requests with AWS X-Ray.",
+		"Tracing.Mode":               "The tracing mode.",
+		"VPC":                        "For network connectivity to AWS resources in a VPC, specify a list of security groups and subnets in the VPC. When you connect a function to a VPC, it can only access resources and the internet through that VPC. For more information, see VPC Settings: https://docs.aws.amazon.com/lambda/latest/dg/configuration-vpc.html.",
+		"VPC.SecurityGroups":         "A list of VPC security groups IDs.",
+		"VPC.Subnets":                "A list of VPC subnet IDs.",
+		"Version":                    "The version of the Lambda function.",
+	}
+}
+
+// Docs returns the documentation for LayerVersionPermission and its fields.
+func (LayerVersionPermission) Docs() map[string]string {
+	return map[string]string{
+		"":               "LayerVersionPermission manages AWS Lambda LayerVersionPermissions.",
+		"Action":         "The API action that grants access to the layer. For example, lambda:GetLayerVersion.",
+		"LayerName":      "The name or Amazon Resource Name (ARN) of the layer.",
+		"OrganizationID": "With the principal set to *, grant permission to all accounts in the specified organization.",
+		"Principal":      "An account ID, or * to grant permission to all AWS accounts.",
+		"RevisionID":     "Only update the policy if the revision ID matches the ID specified. Use this option to avoid modifying a policy that has changed since you last read it.",
+		"Statement":      "The permission statement.",
+		"StatementID":    "An identifier that distinguishes the policy from others on the same layer version.",
+		"VersionNumber":  "The version number.",
+	}
+}
+
+// Docs returns the documentation for Permission and its fields.
+func (Permission) Docs() map[string]string {
+	return map[string]string{
+		"":                 "Permission manages AWS Lambda Permissions.",
+		"Action":           "The action that the principal can use on the function. For example, lambda:InvokeFunction or lambda:GetFunction.",
+		"EventSourceToken": "For Alexa Smart Home functions, a token that must be supplied by the invoker.",
+		"Function":         "The name of the Lambda function, version, or alias.\n\nName formats\n\n  Function name - my-function (name-only), my-function:v1 (with alias).\n  Function ARN - arn:aws:lambda:us-west-2:123456789012:function:my-function.\n  Partial ARN - 123456789012:function:my-function.\n\nYou can append a version number or alias to any of the formats. The length constraint applies only to the full ARN. If you specify only the function name, it is limited to 64 characters in length.",
+		"Principal":        "The AWS service or account that invokes the function. If you specify a service, use SourceArn or SourceAccount to limit who can invoke the function through that service.",
+		"Qualifier":        "Specify a version or alias to add permissions to a published version of the function.",
+		"RevisionID":       "Only update the policy if the revision ID matches the ID that's specified. Use this option to avoid modifying a policy that has changed since you last read it.",
+		"SourceARN":        "For AWS services, the ARN of the AWS resource that invokes the function. For example, an Amazon S3 bucket or Amazon SNS topic.",
+		"SourceAccount":    "For Amazon S3, the ID of the account that owns the resource. Use this together with SourceArn to ensure that the resource is owned by the specified account. It is possible for an Amazon S3 bucket to be deleted by its owner and recreated by another account.",
+		"Statement":        "The permission statement that's added to the function policy.",
+		"StatementID":      "A statement identifier that differentiates the statement from others in the same policy.",
+	}
+}
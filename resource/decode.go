@@ -5,21 +5,36 @@ import (
 	"path/filepath"
 	"reflect"
 	"sort"
+	"strings"
 
 	"github.com/func/func/source"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/ext/customdecode"
 	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/convert"
 )
 
 // Decode decodes a configuration body to a resource graph.
 // Resources must be registered in the schema registry.
-func Decode(body hcl.Body, registry *Registry) (List, hcl.Diagnostics) {
+//
+// Variables in the evaluation context are resolved when decoding. References
+// to anything else are treated as references to other resources. The context
+// may be nil.
+//
+// Ignore files for source code are read from the directory of the
+// configuration file down to the source directory. Use a Loader to read them
+// from the project root.
+func Decode(body hcl.Body, registry *Registry, ctx *hcl.EvalContext) (List, hcl.Diagnostics) {
+	return decode(body, registry, ctx, "")
+}
+
+func decode(body hcl.Body, registry *Registry, ctx *hcl.EvalContext, root string) (List, hcl.Diagnostics) {
 	rootBodySchema := &hcl.BodySchema{
 		Blocks: []hcl.BlockHeaderSchema{
 			{Type: "resource", LabelNames: []string{"name"}},
+			{Type: "project"}, // Decoded by package project
 		},
 	}
 
@@ -30,6 +45,8 @@ func Decode(body hcl.Body, registry *Registry) (List, hcl.Diagnostics) {
 
 	dec := &decoder{
 		Registry:  registry,
+		Context:   ctx,
+		Root:      root,
 		Resources: make(map[string]*decoderResource),
 	}
 
@@ -53,6 +70,9 @@ func Decode(body hcl.Body, registry *Registry) (List, hcl.Diagnostics) {
 	for name, res := range dec.Resources {
 		cfg, _ := registry.New(res.Type)
 		setValue(res.Config, cfg)
+		if res.SourceCode != nil {
+			res.SourceCode.Target = buildTarget(cfg)
+		}
 		out = append(out, &Resource{
 			Name:       name,
 			Type:       res.Type,
@@ -71,6 +91,8 @@ func Decode(body hcl.Body, registry *Registry) (List, hcl.Diagnostics) {
 
 type decoder struct {
 	Registry  *Registry
+	Context   *hcl.EvalContext
+	Root      string // Project root, ignore files are read from here down
 	Resources map[string]*decoderResource
 }
 
@@ -141,11 +163,39 @@ func (d *decoder) DecodeResource(block *hcl.Block) (*decoderResource, hcl.Diagno
 					Name: "build",
 					Type: cty.String,
 				},
+				"include": &hcldec.AttrSpec{
+					Name: "include",
+					Type: cty.List(cty.String),
+				},
+				"exclude": &hcldec.AttrSpec{
+					Name: "exclude",
+					Type: cty.List(cty.String),
+				},
+				"tools": &hcldec.AttrSpec{
+					Name: "tools",
+					Type: cty.List(cty.String),
+				},
+				"env": &hcldec.AttrSpec{
+					Name: "env",
+					Type: cty.Map(cty.String),
+				},
+				"secret_env": &hcldec.AttrSpec{
+					Name: "secret_env",
+					Type: cty.List(cty.String),
+				},
+				"builder": &hcldec.AttrSpec{
+					Name: "builder",
+					Type: cty.String,
+				},
+				"image": &hcldec.AttrSpec{
+					Name: "image",
+					Type: cty.String,
+				},
 			},
 		},
 	}
 
-	content, body, diags := hcldec.PartialDecode(block.Body, spec, nil)
+	content, body, diags := hcldec.PartialDecode(block.Body, spec, d.Context)
 	if diags.HasErrors() {
 		// The type of source did not match the common resource spec.
 		// Don't continue so we can assume the content is valid.
@@ -177,11 +227,17 @@ func (d *decoder) DecodeResource(block *hcl.Block) (*decoderResource, hcl.Diagno
 		cont, _, _ := block.Body.PartialContent(hcldec.ImpliedSchema(spec["source"]))
 		attrs, _ := cont.Blocks[0].Body.JustAttributes()
 		dir := filepath.Join(configDir, srcBlock.GetAttr("dir").AsString())
-		files, err := source.Collect(
-			dir,
+		root := d.Root
+		if root == "" {
+			root = configDir
+		}
+		filters, morediags := sourceFilters(root, dir, srcBlock, attrs)
+		diags = append(diags, morediags...)
+		filters = append([]source.Filter{
 			source.ExcludeHidden(),
 			source.ExcludeFile(configRel),
-		)
+		}, filters...)
+		files, err := source.Collect(dir, filters...)
 		if err != nil {
 			diags = append(diags, &hcl.Diagnostic{
 				Severity: hcl.DiagError,
@@ -191,19 +247,58 @@ func (d *decoder) DecodeResource(block *hcl.Block) (*decoderResource, hcl.Diagno
 			})
 		}
 		src = &source.Code{
-			Files: files,
+			Files:     files,
+			Env:       stringMap(srcBlock.GetAttr("env")),
+			SecretEnv: stringList(srcBlock.GetAttr("secret_env")),
+			Tools:     stringList(srcBlock.GetAttr("tools")),
+		}
+		if imageAttr := srcBlock.GetAttr("image"); !imageAttr.IsNull() {
+			src.Image = imageAttr.AsString()
+			if srcBlock.GetAttr("build").IsNull() && srcBlock.GetAttr("builder").IsNull() {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Missing build script",
+					Detail:   "An image is only used for building the source code, a build script or builder must be set.",
+					Subject:  attrs["image"].Range.Ptr(),
+				})
+			}
 		}
 		if buildAttr := srcBlock.GetAttr("build"); !buildAttr.IsNull() {
-			script, err := source.ParseBuildScript(buildAttr.AsString())
+			if src.Image != "" {
+				// Executables are only available in the container.
+				src.Build = source.ParseContainerBuildScript(buildAttr.AsString())
+			} else {
+				script, err := source.ParseBuildScript(buildAttr.AsString())
+				if err != nil {
+					diags = append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Invalid build script",
+						Detail:   fmt.Sprintf("Error: %v.", err),
+						Subject:  attrs["build"].Range.Ptr(),
+					})
+				}
+				src.Build = script
+			}
+		}
+		if builderAttr := srcBlock.GetAttr("builder"); !builderAttr.IsNull() {
+			builder, err := source.NewBuilder(builderAttr.AsString())
 			if err != nil {
 				diags = append(diags, &hcl.Diagnostic{
 					Severity: hcl.DiagError,
-					Summary:  "Invalid build script",
-					Detail:   fmt.Sprintf("Error: %v.", err),
-					Subject:  attrs["build"].Range.Ptr(),
+					Summary:  "Unsupported builder",
+					Detail:   fmt.Sprintf("Error: %v. Supported builders are: %s.", err, strings.Join(source.Builders(), ", ")),
+					Subject:  attrs["builder"].Range.Ptr(),
 				})
 			}
-			src.Build = script
+			if len(src.Build) > 0 {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Conflicting build options",
+					Detail:   "A source block cannot set both build and builder.",
+					Subject:  attrs["builder"].Range.Ptr(),
+				})
+			}
+			src.Builder = builder
 		}
 	}
 
@@ -224,6 +319,122 @@ func (d *decoder) DecodeResource(block *hcl.Block) (*decoderResource, hcl.Diagno
 	}, diags
 }
 
+// ignoreFiles are read from the directories between the project root and
+// the source directory and from sub directories of the source directory.
+// Patterns in later files take precedence.
+var ignoreFiles = []string{".gitignore", ".funcignore"}
+
+// sourceFilters returns the filters for collecting source files, from the
+// ignore files from the root down to the source directory and the include
+// and exclude attributes in the source block.
+func sourceFilters(root, dir string, srcBlock cty.Value, attrs hcl.Attributes) ([]source.Filter, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	var filters []source.Filter
+	ignore, err := source.Ignore(root, dir, ignoreFiles...)
+	if err != nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Could not read ignore file",
+			Detail:   fmt.Sprintf("Error: %v.", err),
+			Subject:  attrs["dir"].Range.Ptr(),
+		})
+	} else {
+		filters = append(filters, ignore)
+	}
+
+	if exclude := stringList(srcBlock.GetAttr("exclude")); len(exclude) > 0 {
+		filter, err := source.Exclude(exclude...)
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid exclude pattern",
+				Detail:   fmt.Sprintf("Error: %v.", err),
+				Subject:  attrs["exclude"].Range.Ptr(),
+			})
+		} else {
+			filters = append(filters, filter)
+		}
+	}
+	if include := stringList(srcBlock.GetAttr("include")); len(include) > 0 {
+		filter, err := source.Include(include...)
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid include pattern",
+				Detail:   fmt.Sprintf("Error: %v.", err),
+				Subject:  attrs["include"].Range.Ptr(),
+			})
+		} else {
+			filters = append(filters, filter)
+		}
+	}
+	return filters, diags
+}
+
+func stringList(val cty.Value) []string {
+	if val.IsNull() || !val.IsKnown() {
+		return nil
+	}
+	var out []string
+	for _, v := range val.AsValueSlice() {
+		if v.IsNull() {
+			continue
+		}
+		out = append(out, v.AsString())
+	}
+	return out
+}
+
+// buildTarget returns the build target from the runtime, handler and
+// architectures inputs of a resource config. Inputs that are not set or not
+// supported by the resource are left empty.
+func buildTarget(cfg reflect.Value) source.Target {
+	if cfg.Kind() == reflect.Ptr {
+		cfg = cfg.Elem()
+	}
+	fields := inputFields(cfg.Type())
+	str := func(name string) string {
+		index, ok := fields[name]
+		if !ok {
+			return ""
+		}
+		v := cfg.Field(index)
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return ""
+			}
+			v = v.Elem()
+		}
+		switch {
+		case v.Kind() == reflect.String:
+			return v.String()
+		case v.Kind() == reflect.Slice && v.Len() > 0 && v.Index(0).Kind() == reflect.String:
+			return v.Index(0).String()
+		}
+		return ""
+	}
+	return source.Target{
+		Runtime: str("runtime"),
+		Handler: str("handler"),
+		Arch:    str("architectures"),
+	}
+}
+
+func stringMap(val cty.Value) map[string]string {
+	if val.IsNull() || !val.IsKnown() {
+		return nil
+	}
+	out := make(map[string]string)
+	for k, v := range val.AsValueMap() {
+		if v.IsNull() {
+			continue
+		}
+		out[k] = v.AsString()
+	}
+	return out
+}
+
 func (d *decoder) ResolveStatic() hcl.Diagnostics {
 	var diags hcl.Diagnostics
 	for _, res := range d.Resources {
@@ -238,17 +449,23 @@ func (d *decoder) ResolveStatic() hcl.Diagnostics {
 			}
 
 			expr := customdecode.ExpressionFromVal(value)
-			if len(expr.Variables()) > 0 {
+			if d.hasReferences(expr) {
 				// Reference to other resource
+				bound, morediags := bindContext(expr, d.Context)
+				diags = append(diags, morediags...)
 				res.Refs = append(res.Refs, Reference{
 					Field:      path,
-					Expression: expr,
+					Expression: bound,
 				})
 				return cty.DynamicVal, nil
 			}
 
 			// Can be statically resolved
-			val, _ := expr.Value(nil)
+			val, morediags := expr.Value(d.Context)
+			diags = append(diags, morediags...)
+			if morediags.HasErrors() {
+				return cty.DynamicVal, nil
+			}
 
 			// Convert if needed
 			wantType := applyTypePath(res.Input, path)
@@ -288,6 +505,137 @@ func (d *decoder) ResolveStatic() hcl.Diagnostics {
 	return diags
 }
 
+// hasReferences returns true if the expression references other resources.
+// Variables in the evaluation context are not references.
+func (d *decoder) hasReferences(expr hcl.Expression) bool {
+	for _, trav := range expr.Variables() {
+		if !isContextVar(d.Context, trav.RootName()) {
+			return true
+		}
+	}
+	return false
+}
+
+func isContextVar(ctx *hcl.EvalContext, name string) bool {
+	if ctx == nil {
+		return false
+	}
+	_, ok := ctx.Variables[name]
+	return ok
+}
+
+// bindContext replaces all parts of the expression that only refer to
+// variables in the evaluation context with their literal values. References to
+// other resources are left as-is.
+func bindContext(expr hcl.Expression, ctx *hcl.EvalContext) (hcl.Expression, hcl.Diagnostics) {
+	e, ok := expr.(hclsyntax.Expression)
+	if ctx == nil || !ok {
+		return expr, nil
+	}
+	bound, diags := bindExpr(e, ctx)
+	if diags.HasErrors() {
+		return expr, diags
+	}
+
+	// Variables in expressions that cannot be bound would be treated as
+	// references to resources.
+	diags = append(diags, hclsyntax.VisitAll(bound, func(node hclsyntax.Node) hcl.Diagnostics {
+		trav, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if !ok || !isContextVar(ctx, trav.Traversal.RootName()) {
+			return nil
+		}
+		return hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported use of variable",
+			Detail:   "Variables cannot be used in this expression together with references to other resources.",
+			Subject:  trav.SrcRange.Ptr(),
+		}}
+	})...)
+	return bound, diags
+}
+
+func bindExpr(expr hclsyntax.Expression, ctx *hcl.EvalContext) (hclsyntax.Expression, hcl.Diagnostics) {
+	vars := expr.Variables()
+	if len(vars) == 0 {
+		return expr, nil
+	}
+	static := true
+	for _, trav := range vars {
+		if !isContextVar(ctx, trav.RootName()) {
+			static = false
+			break
+		}
+	}
+	if static {
+		val, diags := expr.Value(ctx)
+		if diags.HasErrors() {
+			return expr, diags
+		}
+		return &hclsyntax.LiteralValueExpr{Val: val, SrcRange: expr.Range()}, diags
+	}
+
+	var diags hcl.Diagnostics
+	bind := func(e hclsyntax.Expression) hclsyntax.Expression {
+		bound, morediags := bindExpr(e, ctx)
+		diags = append(diags, morediags...)
+		return bound
+	}
+	bindAll := func(exprs []hclsyntax.Expression) []hclsyntax.Expression {
+		out := make([]hclsyntax.Expression, len(exprs))
+		for i, e := range exprs {
+			out[i] = bind(e)
+		}
+		return out
+	}
+
+	switch e := expr.(type) {
+	case *hclsyntax.TemplateExpr:
+		return &hclsyntax.TemplateExpr{Parts: bindAll(e.Parts), SrcRange: e.SrcRange}, diags
+	case *hclsyntax.TemplateWrapExpr:
+		return &hclsyntax.TemplateWrapExpr{Wrapped: bind(e.Wrapped), SrcRange: e.SrcRange}, diags
+	case *hclsyntax.FunctionCallExpr:
+		out := *e
+		out.Args = bindAll(e.Args)
+		return &out, diags
+	case *hclsyntax.ConditionalExpr:
+		out := *e
+		out.Condition = bind(e.Condition)
+		out.TrueResult = bind(e.TrueResult)
+		out.FalseResult = bind(e.FalseResult)
+		return &out, diags
+	case *hclsyntax.BinaryOpExpr:
+		out := *e
+		out.LHS = bind(e.LHS)
+		out.RHS = bind(e.RHS)
+		return &out, diags
+	case *hclsyntax.UnaryOpExpr:
+		out := *e
+		out.Val = bind(e.Val)
+		return &out, diags
+	case *hclsyntax.IndexExpr:
+		out := *e
+		out.Collection = bind(e.Collection)
+		out.Key = bind(e.Key)
+		return &out, diags
+	case *hclsyntax.TupleConsExpr:
+		out := *e
+		out.Exprs = bindAll(e.Exprs)
+		return &out, diags
+	case *hclsyntax.ObjectConsExpr:
+		out := *e
+		out.Items = make([]hclsyntax.ObjectConsItem, len(e.Items))
+		for i, item := range e.Items {
+			out.Items[i] = hclsyntax.ObjectConsItem{
+				KeyExpr:   item.KeyExpr,
+				ValueExpr: bind(item.ValueExpr),
+			}
+		}
+		return &out, diags
+	default:
+		return expr, nil
+	}
+}
+
 func applyTypePath(ty cty.Type, path cty.Path) cty.Type {
 	for _, p := range path {
 		switch e := p.(type) {
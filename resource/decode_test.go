@@ -16,6 +16,8 @@ import (
 	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
 	"golang.org/x/tools/txtar"
 )
 
@@ -23,6 +25,7 @@ func TestDecoder_Decode(t *testing.T) {
 	tests := []struct {
 		name      string
 		input     string
+		ctx       *hcl.EvalContext
 		want      resource.List
 		wantDiags hcl.Diagnostics
 	}{
@@ -222,6 +225,10 @@ module.exports = function() {}
 							Root:  "<DIR>",
 							Files: []string{"index.js"},
 						},
+						Target: source.Target{
+							Runtime: "nodejs10.x",
+							Handler: "index.handler",
+						},
 					},
 				},
 			},
@@ -265,6 +272,130 @@ module.exports = function() {}
 							Root:  "<DIR>/a/b/c/source",
 							Files: []string{"index.js"},
 						},
+						Target: source.Target{
+							Runtime: "nodejs10.x",
+							Handler: "index.handler",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "SourceFilter",
+			input: `
+-- file.hcl --
+resource "func" {
+	type    = "aws:lambda_function"
+	handler = "index.handler"
+	runtime = "nodejs10.x"
+	role    = "testrole"
+
+	source {
+		dir     = "src"
+		include = ["*.js", "*.json"]
+		exclude = ["fixtures/"]
+	}
+}
+
+-- src/.gitignore --
+coverage/
+*.log
+-- src/.funcignore --
+*.test.js
+-- src/index.js --
+module.exports = function() {}
+-- src/index.test.js --
+test()
+-- src/package.json --
+{}
+-- src/README.md --
+# Test
+-- src/coverage/lcov.json --
+{}
+-- src/fixtures/event.json --
+{}
+			`,
+			want: resource.List{
+				{
+					Name: "func",
+					Type: "aws:lambda_function",
+					Definition: hcl.Range{
+						Filename: "<DIR>/file.hcl",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
+					},
+					Config: LambdaFunction{
+						Handler: "index.handler",
+						Runtime: "nodejs10.x",
+						Role:    "testrole",
+					},
+					SourceCode: &source.Code{
+						Files: &source.FileList{
+							Root:  "<DIR>/src",
+							Files: []string{"index.js", "package.json"},
+						},
+						Target: source.Target{
+							Runtime: "nodejs10.x",
+							Handler: "index.handler",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "SourceParentIgnore",
+			input: `
+-- file.hcl --
+resource "func" {
+	type    = "aws:lambda_function"
+	handler = "index.handler"
+	runtime = "nodejs10.x"
+	role    = "testrole"
+
+	source {
+		dir = "src"
+	}
+}
+
+-- .gitignore --
+node_modules/
+*.log
+-- src/lib/.funcignore --
+*.test.js
+-- src/index.js --
+module.exports = function() {}
+-- src/debug.log --
+log
+-- src/lib/util.js --
+module.exports = {}
+-- src/lib/util.test.js --
+test()
+-- src/node_modules/dep/index.js --
+module.exports = {}
+			`,
+			want: resource.List{
+				{
+					Name: "func",
+					Type: "aws:lambda_function",
+					Definition: hcl.Range{
+						Filename: "<DIR>/file.hcl",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
+					},
+					Config: LambdaFunction{
+						Handler: "index.handler",
+						Runtime: "nodejs10.x",
+						Role:    "testrole",
+					},
+					SourceCode: &source.Code{
+						Files: &source.FileList{
+							Root:  "<DIR>/src",
+							Files: []string{"index.js", "lib/util.js"},
+						},
+						Target: source.Target{
+							Runtime: "nodejs10.x",
+							Handler: "index.handler",
+						},
 					},
 				},
 			},
@@ -315,6 +446,10 @@ func main() {}
 								"main.go",
 							},
 						},
+						Target: source.Target{
+							Runtime: "go1.x",
+							Handler: "test",
+						},
 						Build: source.BuildScript{
 							"go build .",
 						},
@@ -323,6 +458,431 @@ func main() {}
 			},
 		},
 
+		{
+			name: "SourceEnv",
+			input: `
+-- file.hcl --
+resource "func" {
+	type    = "aws:lambda_function"
+	handler = "test"
+	runtime = "go1.x"
+	role    = "testrole"
+
+	source {
+		dir        = "."
+		env        = {
+			GOOS  = "linux"
+			STAGE = env.name
+		}
+		secret_env = ["NPM_TOKEN"]
+	}
+}
+
+-- main.go --
+package main
+			`,
+			ctx: &hcl.EvalContext{
+				Variables: map[string]cty.Value{
+					"env": cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("prod")}),
+				},
+			},
+			want: resource.List{
+				{
+					Name: "func",
+					Type: "aws:lambda_function",
+					Definition: hcl.Range{
+						Filename: "<DIR>/file.hcl",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
+					},
+					Config: LambdaFunction{
+						Handler: "test",
+						Runtime: "go1.x",
+						Role:    "testrole",
+					},
+					SourceCode: &source.Code{
+						Files: &source.FileList{
+							Root:  "<DIR>",
+							Files: []string{"main.go"},
+						},
+						Target: source.Target{
+							Runtime: "go1.x",
+							Handler: "test",
+						},
+						Env: map[string]string{
+							"GOOS":  "linux",
+							"STAGE": "prod",
+						},
+						SecretEnv: []string{"NPM_TOKEN"},
+					},
+				},
+			},
+		},
+
+		{
+			name: "SourceBuilder",
+			input: `
+-- file.hcl --
+resource "func" {
+	type    = "aws:lambda_function"
+	handler = "handler"
+	runtime = "go1.x"
+	role    = "testrole"
+
+	source {
+		dir     = "."
+		builder = "go"
+	}
+}
+
+-- main.go --
+package main
+			`,
+			want: resource.List{
+				{
+					Name: "func",
+					Type: "aws:lambda_function",
+					Definition: hcl.Range{
+						Filename: "<DIR>/file.hcl",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
+					},
+					Config: LambdaFunction{
+						Handler: "handler",
+						Runtime: "go1.x",
+						Role:    "testrole",
+					},
+					SourceCode: &source.Code{
+						Files: &source.FileList{
+							Root:  "<DIR>",
+							Files: []string{"main.go"},
+						},
+						Builder: &source.GoBuilder{},
+						Target: source.Target{
+							Runtime: "go1.x",
+							Handler: "handler",
+						},
+					},
+				},
+			},
+		},
+
+		{
+			name: "SourceImageBuilder",
+			input: `
+-- file.hcl --
+resource "func" {
+	type    = "aws:lambda_function"
+	handler = "handler"
+	runtime = "go1.x"
+	role    = "testrole"
+
+	source {
+		dir     = "."
+		image   = "golang:1.15"
+		builder = "go"
+	}
+}
+
+-- main.go --
+package main
+			`,
+			want: resource.List{
+				{
+					Name: "func",
+					Type: "aws:lambda_function",
+					Definition: hcl.Range{
+						Filename: "<DIR>/file.hcl",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
+					},
+					Config: LambdaFunction{
+						Handler: "handler",
+						Runtime: "go1.x",
+						Role:    "testrole",
+					},
+					SourceCode: &source.Code{
+						Files: &source.FileList{
+							Root:  "<DIR>",
+							Files: []string{"main.go"},
+						},
+						Image:   "golang:1.15",
+						Builder: &source.GoBuilder{},
+						Target: source.Target{
+							Runtime: "go1.x",
+							Handler: "handler",
+						},
+					},
+				},
+			},
+		},
+
+		{
+			name: "SourceImage",
+			input: `
+-- file.hcl --
+resource "func" {
+	type    = "aws:lambda_function"
+	handler = "index.handler"
+	runtime = "nodejs12.x"
+	role    = "testrole"
+
+	source {
+		dir   = "."
+		image = "node:12"
+		build = "npm ci"
+	}
+}
+
+-- index.js --
+exports.handler = async () => {}
+			`,
+			want: resource.List{
+				{
+					Name: "func",
+					Type: "aws:lambda_function",
+					Definition: hcl.Range{
+						Filename: "<DIR>/file.hcl",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
+					},
+					Config: LambdaFunction{
+						Handler: "index.handler",
+						Runtime: "nodejs12.x",
+						Role:    "testrole",
+					},
+					SourceCode: &source.Code{
+						Files: &source.FileList{
+							Root:  "<DIR>",
+							Files: []string{"index.js"},
+						},
+						Image: "node:12",
+						Build: source.BuildScript{"npm ci"},
+						Target: source.Target{
+							Runtime: "nodejs12.x",
+							Handler: "index.handler",
+						},
+					},
+				},
+			},
+		},
+		// Project
+		{
+			name: "IgnoreProject",
+			input: `
+-- func.hcl --
+project {
+	stack = "test"
+}
+
+resource "func" {
+	type    = "aws:lambda_function"
+	handler = "index.handler"
+	runtime = "nodejs10.x"
+	role    = "testrole"
+}
+			`,
+			want: resource.List{
+				{
+					Name: "func",
+					Type: "aws:lambda_function",
+					Definition: hcl.Range{
+						Filename: "<DIR>/func.hcl",
+						Start:    hcl.Pos{Line: 5, Column: 1, Byte: 29},
+						End:      hcl.Pos{Line: 5, Column: 16, Byte: 44},
+					},
+					Config: LambdaFunction{
+						Handler: "index.handler",
+						Runtime: "nodejs10.x",
+						Role:    "testrole",
+					},
+				},
+			},
+		},
+
+		// Context
+		{
+			name: "ContextVariables",
+			input: `
+-- file.hcl --
+resource "func" {
+	type        = "aws:lambda_function"
+	handler     = "index.handler"
+	runtime     = "nodejs10.x"
+	role        = "${env.name}-role"
+	memory_size = var.memory
+}
+			`,
+			ctx: &hcl.EvalContext{
+				Variables: map[string]cty.Value{
+					"env": cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("prod")}),
+					"var": cty.ObjectVal(map[string]cty.Value{"memory": cty.NumberIntVal(512)}),
+				},
+			},
+			want: resource.List{
+				{
+					Name: "func",
+					Type: "aws:lambda_function",
+					Definition: hcl.Range{
+						Filename: "<DIR>/file.hcl",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
+					},
+					Config: LambdaFunction{
+						Handler:    "index.handler",
+						Runtime:    "nodejs10.x",
+						Role:       "prod-role",
+						MemorySize: intptr(512),
+					},
+				},
+			},
+		},
+		{
+			name: "ContextInReference",
+			input: `
+-- file.hcl --
+resource "a" {
+	type    = "aws:lambda_function"
+	handler = "index.handler"
+	runtime = "nodejs10.x"
+	role    = "testrole"
+}
+
+resource "b" {
+	type    = "aws:lambda_function"
+	handler = "index.handler"
+	runtime = "nodejs10.x"
+	role    = "${env.name}-${a.role}"
+}
+			`,
+			ctx: &hcl.EvalContext{
+				Variables: map[string]cty.Value{
+					"env": cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("prod")}),
+				},
+			},
+			want: resource.List{
+				{
+					Name: "a",
+					Type: "aws:lambda_function",
+					Definition: hcl.Range{
+						Filename: "<DIR>/file.hcl",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
+					},
+					Config: LambdaFunction{
+						Handler: "index.handler",
+						Runtime: "nodejs10.x",
+						Role:    "testrole",
+					},
+				},
+				{
+					Name: "b",
+					Type: "aws:lambda_function",
+					Definition: hcl.Range{
+						Filename: "<DIR>/file.hcl",
+						Start:    hcl.Pos{Line: 8, Column: 1, Byte: 124},
+						End:      hcl.Pos{Line: 8, Column: 13, Byte: 136},
+					},
+					Config: LambdaFunction{
+						Handler: "index.handler",
+						Runtime: "nodejs10.x",
+					},
+					Refs: []resource.Reference{
+						{
+							Field: cty.GetAttrPath("role"),
+							Expression: &hclsyntax.TemplateExpr{
+								Parts: []hclsyntax.Expression{
+									&hclsyntax.LiteralValueExpr{Val: cty.StringVal("prod")},
+									&hclsyntax.LiteralValueExpr{Val: cty.StringVal("-")},
+									&hclsyntax.ScopeTraversalExpr{
+										Traversal: hcl.Traversal{
+											hcl.TraverseRoot{Name: "a"},
+											hcl.TraverseAttr{Name: "role"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ContextInReferenceExpression",
+			input: `
+-- file.hcl --
+resource "a" {
+	type    = "aws:lambda_function"
+	handler = "index.handler"
+	runtime = "nodejs10.x"
+	role    = "testrole"
+}
+
+resource "b" {
+	type    = "aws:lambda_function"
+	handler = "index.handler"
+	runtime = "nodejs10.x"
+	role    = "${upper(env.name)}-${env.name == "prod" ? "p" : "d"}-${a.role}"
+}
+			`,
+			ctx: &hcl.EvalContext{
+				Variables: map[string]cty.Value{
+					"env": cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("prod")}),
+				},
+				Functions: map[string]function.Function{
+					"upper": stdlib.UpperFunc,
+				},
+			},
+			want: resource.List{
+				{
+					Name: "a",
+					Type: "aws:lambda_function",
+					Definition: hcl.Range{
+						Filename: "<DIR>/file.hcl",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
+					},
+					Config: LambdaFunction{
+						Handler: "index.handler",
+						Runtime: "nodejs10.x",
+						Role:    "testrole",
+					},
+				},
+				{
+					Name: "b",
+					Type: "aws:lambda_function",
+					Definition: hcl.Range{
+						Filename: "<DIR>/file.hcl",
+						Start:    hcl.Pos{Line: 8, Column: 1, Byte: 124},
+						End:      hcl.Pos{Line: 8, Column: 13, Byte: 136},
+					},
+					Config: LambdaFunction{
+						Handler: "index.handler",
+						Runtime: "nodejs10.x",
+					},
+					Refs: []resource.Reference{
+						{
+							Field: cty.GetAttrPath("role"),
+							Expression: &hclsyntax.TemplateExpr{
+								Parts: []hclsyntax.Expression{
+									&hclsyntax.LiteralValueExpr{Val: cty.StringVal("PROD")},
+									&hclsyntax.LiteralValueExpr{Val: cty.StringVal("-")},
+									&hclsyntax.LiteralValueExpr{Val: cty.StringVal("p")},
+									&hclsyntax.LiteralValueExpr{Val: cty.StringVal("-")},
+									&hclsyntax.ScopeTraversalExpr{
+										Traversal: hcl.Traversal{
+											hcl.TraverseRoot{Name: "a"},
+											hcl.TraverseAttr{Name: "role"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+
 		// References
 		{
 			name: "ReferenceToInput",
@@ -1033,7 +1593,7 @@ resource "b" {
 				}, cmp.Ignore()),
 			}
 
-			got, diags := resource.Decode(body, reg)
+			got, diags := resource.Decode(body, reg, tc.ctx)
 			if diff := cmp.Diff(diags, tc.wantDiags, opts...); diff != "" {
 				t.Fatalf(
 					"Diagnostics do not match\n\nGot\n%s\n\nWant\n%s\n\nDiff (-got +want):\n%s",
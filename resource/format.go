@@ -0,0 +1,153 @@
+package resource
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// metaArguments are the arguments that are common to all resources, in the
+// order they are formatted.
+var metaArguments = []string{"type", "source", "count", "depends_on"}
+
+// Format formats a resource configuration file in canonical style.
+//
+// In addition to formatting, meta arguments are moved to the top of each
+// resource block. Comments on the moved arguments are retained.
+func Format(src []byte, filename string) ([]byte, hcl.Diagnostics) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	body := file.Body.(*hclsyntax.Body)
+
+	out := append([]byte(nil), src...)
+	// Process blocks from the end, so the offsets of the preceding blocks
+	// remain valid.
+	for i := len(body.Blocks) - 1; i >= 0; i-- {
+		block := body.Blocks[i]
+		if block.Type != "resource" {
+			continue
+		}
+		out = orderMetaArguments(out, block.Body)
+	}
+	return hclwrite.Format(out), nil
+}
+
+// bodyItem is an attribute or block in a body, including its leading
+// comments.
+type bodyItem struct {
+	name       string
+	start, end int
+}
+
+func orderMetaArguments(src []byte, body *hclsyntax.Body) []byte {
+	var items []bodyItem
+	for name, attr := range body.Attributes {
+		items = append(items, bodyItem{name, attr.SrcRange.Start.Byte, attr.SrcRange.End.Byte})
+	}
+	for _, b := range body.Blocks {
+		items = append(items, bodyItem{b.Type, b.TypeRange.Start.Byte, b.CloseBraceRange.End.Byte})
+	}
+	if len(items) == 0 {
+		return src
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].start < items[j].start })
+
+	for i, item := range items {
+		start, end, ok := itemLines(src, item.start, item.end)
+		if !ok {
+			// Items share lines with other content, leave as is.
+			return src
+		}
+		items[i].start, items[i].end = start, end
+	}
+
+	var meta []bodyItem
+	for _, name := range metaArguments {
+		for _, item := range items {
+			if item.name == name {
+				meta = append(meta, item)
+			}
+		}
+	}
+	sorted := true
+	for i, item := range meta {
+		if items[i] != item {
+			sorted = false
+			break
+		}
+	}
+	if sorted {
+		return src
+	}
+
+	first, last := items[0].start, items[len(items)-1].end
+	var buf bytes.Buffer
+	buf.Write(src[:first])
+	for _, item := range meta {
+		buf.Write(src[item.start:item.end])
+	}
+	// Retain the content between the remaining items, such as blank lines and
+	// detached comments.
+	pos, wrote := first, false
+	for _, item := range items {
+		gap := src[pos:item.start]
+		pos = item.end
+		if isMetaArgument(item.name) {
+			if len(bytes.TrimSpace(gap)) > 0 {
+				buf.Write(gap)
+			}
+			continue
+		}
+		if !wrote {
+			gap = bytes.TrimLeft(gap, " \t\r\n")
+			wrote = true
+		}
+		buf.Write(gap)
+		buf.Write(src[item.start:item.end])
+	}
+	buf.Write(src[last:])
+	return buf.Bytes()
+}
+
+// itemLines extends the range of an item to the full lines it is on, including
+// any comments directly above it. Returns false if the lines contain other
+// content than the item.
+func itemLines(src []byte, start, end int) (int, int, bool) {
+	lineStart := bytes.LastIndexByte(src[:start], '\n') + 1
+	if len(bytes.TrimSpace(src[lineStart:start])) > 0 {
+		return 0, 0, false
+	}
+	lineEnd := len(src)
+	if i := bytes.IndexByte(src[end:], '\n'); i >= 0 {
+		lineEnd = end + i + 1
+	}
+	if trailing := bytes.TrimSpace(src[end:lineEnd]); len(trailing) > 0 && !isComment(trailing) {
+		return 0, 0, false
+	}
+	for lineStart > 0 {
+		prev := bytes.LastIndexByte(src[:lineStart-1], '\n') + 1
+		if !isComment(bytes.TrimSpace(src[prev:lineStart])) {
+			break
+		}
+		lineStart = prev
+	}
+	return lineStart, lineEnd, true
+}
+
+func isComment(line []byte) bool {
+	return bytes.HasPrefix(line, []byte("#")) || bytes.HasPrefix(line, []byte("//"))
+}
+
+func isMetaArgument(name string) bool {
+	for _, m := range metaArguments {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name: "Whitespace",
+			input: `resource "foo" {
+type="aws:lambda_function"
+  handler   =    "index.handler"
+    memory_size = 128
+}
+`,
+			want: `resource "foo" {
+  type        = "aws:lambda_function"
+  handler     = "index.handler"
+  memory_size = 128
+}
+`,
+		},
+		{
+			name: "OrderMetaArguments",
+			input: `resource "foo" {
+  handler = "index.handler"
+
+  # The function
+  type = "aws:lambda_function" # inline
+
+  environment {
+    variables = {}
+  }
+  depends_on = [bar]
+  source {
+    dir = "src"
+  }
+}
+`,
+			want: `resource "foo" {
+  # The function
+  type = "aws:lambda_function" # inline
+  source {
+    dir = "src"
+  }
+  depends_on = [bar]
+  handler    = "index.handler"
+
+  environment {
+    variables = {}
+  }
+}
+`,
+		},
+		{
+			name: "AlreadyOrdered",
+			input: `resource "foo" {
+  type = "aws:lambda_function"
+
+  handler = "index.handler"
+}
+`,
+			want: `resource "foo" {
+  type = "aws:lambda_function"
+
+  handler = "index.handler"
+}
+`,
+		},
+		{
+			name: "OtherBlocks",
+			input: `project "foo" {
+  stack = "a"
+  type  = "b"
+}
+`,
+			want: `project "foo" {
+  stack = "a"
+  type  = "b"
+}
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, diags := Format([]byte(tc.input), "file.hcl")
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			if diff := cmp.Diff(string(got), tc.want); diff != "" {
+				t.Errorf("Diff (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormat_invalid(t *testing.T) {
+	_, diags := Format([]byte(`resource "foo" {`), "file.hcl")
+	if !diags.HasErrors() {
+		t.Errorf("Want error")
+	}
+}
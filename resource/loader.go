@@ -11,27 +11,23 @@ import (
 type Loader struct {
 	Registry *Registry
 
+	// Context is used for evaluating expressions in the loaded resources. The
+	// context is optional.
+	Context *hcl.EvalContext
+
 	parser *Parser
 }
 
 // LoadDir loads the resource graph from a given directory and all sub
 // directories. All .hcl files are parsed and decoded to the resulting graph.
+//
+// The directory is the project root, ignore files for source code are read
+// from it down to the source directory.
 func (l *Loader) LoadDir(dir string) (List, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
-	var files []string
-	if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if info.IsDir() {
-			return nil
-		}
-		if filepath.Ext(info.Name()) == ".hcl" {
-			files = append(files, path)
-		}
-		return nil
-	}); err != nil {
+	files, err := ConfigFiles(dir)
+	if err != nil {
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagError,
 			Summary:  "Could not read config files",
@@ -45,12 +41,32 @@ func (l *Loader) LoadDir(dir string) (List, hcl.Diagnostics) {
 	}
 	body := l.parser.Body()
 
-	g, morediags := Decode(body, l.Registry)
+	root, _ := filepath.Abs(dir)
+	g, morediags := decode(body, l.Registry, l.Context, root)
 	diags = append(diags, morediags...)
 
 	return g, diags
 }
 
+// ConfigFiles returns all resource configuration files in the given directory
+// and all sub directories.
+func ConfigFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if filepath.Ext(info.Name()) == ".hcl" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
 // Files returns all the loaded files, keyed by file name.
 func (l *Loader) Files() map[string]*hcl.File {
 	return l.parser.Files()
@@ -0,0 +1,140 @@
+package resource
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Markdown writes a reference page for the resource type in markdown. The
+// page lists the inputs and outputs as they are used in resource
+// configuration files and contains an example resource block.
+func (s *Schema) Markdown(w io.Writer) error {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# %s\n\n", s.Type)
+	if s.Doc != "" {
+		fmt.Fprintf(&buf, "%s\n\n", s.Doc)
+	}
+	if s.CloudFormationType != "" {
+		fmt.Fprintf(&buf, "CloudFormation type: `%s`\n\n", s.CloudFormationType)
+	}
+
+	fmt.Fprintf(&buf, "## Example\n\n")
+	fmt.Fprintf(&buf, "```hcl\n")
+	fmt.Fprintf(&buf, "resource %q {\n", exampleName(s.Type))
+	fmt.Fprintf(&buf, "  type = %q\n", s.Type)
+	if s.Source {
+		fmt.Fprintf(&buf, "\n  source {\n")
+		fmt.Fprintf(&buf, "    dir = \"src\"\n")
+		fmt.Fprintf(&buf, "  }\n")
+	}
+	markdownExample(&buf, s.Inputs, "  ")
+	fmt.Fprintf(&buf, "}\n")
+	fmt.Fprintf(&buf, "```\n")
+
+	if len(s.Inputs) > 0 {
+		fmt.Fprintf(&buf, "\n## Inputs\n")
+		markdownAttributes(&buf, s.Inputs, "", true)
+	}
+	if len(s.Outputs) > 0 {
+		fmt.Fprintf(&buf, "\n## Outputs\n")
+		markdownAttributes(&buf, s.Outputs, "", false)
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// markdownAttributes writes a section for each attribute. Attributes of nested
+// blocks are written after the block, with the block name as prefix.
+func markdownAttributes(w io.Writer, attrs []Attribute, prefix string, input bool) {
+	for _, attr := range attrs {
+		name := prefix + attr.Name
+		fmt.Fprintf(w, "\n### `%s`\n\n", name)
+		fmt.Fprintf(w, "- Type: `%s`\n", attr.Type)
+		if input {
+			fmt.Fprintf(w, "- Required: %s\n", yesNo(attr.Required))
+		} else {
+			ref := "not available in CloudFormation"
+			if attr.Reference != "" {
+				ref = "`" + attr.Reference + "`"
+			}
+			fmt.Fprintf(w, "- Reference: %s\n", ref)
+		}
+		if attr.Doc != "" {
+			fmt.Fprintf(w, "\n%s\n", attr.Doc)
+		}
+		markdownAttributes(w, attr.Attributes, name+".", input)
+	}
+}
+
+// markdownExample writes the required attributes as HCL, with placeholder
+// values.
+func markdownExample(w io.Writer, attrs []Attribute, indent string) {
+	var values, blocks []Attribute
+	for _, attr := range attrs {
+		if !attr.Required {
+			continue
+		}
+		if attr.Type == "block" || attr.Type == "list(block)" {
+			blocks = append(blocks, attr)
+			continue
+		}
+		values = append(values, attr)
+	}
+
+	if len(values) > 0 {
+		// Separate from the type or the block header.
+		fmt.Fprint(w, "\n")
+	}
+	// Align equals signs, as the formatter would.
+	width := 0
+	for _, attr := range values {
+		if len(attr.Name) > width {
+			width = len(attr.Name)
+		}
+	}
+	for _, attr := range values {
+		fmt.Fprintf(w, "%s%-*s = %s\n", indent, width, attr.Name, exampleValue(attr.Type))
+	}
+
+	for _, attr := range blocks {
+		fmt.Fprintf(w, "\n%s%s {\n", indent, attr.Name)
+		markdownExample(w, attr.Attributes, indent+"  ")
+		fmt.Fprintf(w, "%s}\n", indent)
+	}
+}
+
+// exampleValue returns a placeholder value for an attribute type.
+func exampleValue(typ string) string {
+	switch {
+	case typ == "string":
+		return `"..."`
+	case typ == "number":
+		return "1"
+	case typ == "bool":
+		return "true"
+	case strings.HasPrefix(typ, "list("):
+		return "[" + exampleValue(strings.TrimSuffix(strings.TrimPrefix(typ, "list("), ")")) + "]"
+	default:
+		return "{}"
+	}
+}
+
+// exampleName returns the name of the example resource, the type name without
+// the provider prefix.
+func exampleName(typename string) string {
+	if i := strings.LastIndex(typename, ":"); i >= 0 {
+		return typename[i+1:]
+	}
+	return typename
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
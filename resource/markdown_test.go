@@ -0,0 +1,40 @@
+package resource
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "Update golden files")
+
+func TestSchema_Markdown(t *testing.T) {
+	reg := &Registry{}
+	reg.Add("test:resource", reflect.TypeOf(&schemaTest{}))
+	schema, err := reg.Schema("test:resource")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := schema.Markdown(&buf); err != nil {
+		t.Fatalf("Markdown() err = %v", err)
+	}
+
+	golden := filepath.Join("testdata", "markdown.golden")
+	if *update {
+		if err := ioutil.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != string(want) {
+		t.Errorf("Markdown does not match %s, run with -update to update\nGot:\n%s\nWant:\n%s", golden, got, want)
+	}
+}
@@ -0,0 +1,318 @@
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/func/func/source"
+)
+
+// Documented is implemented by resource configs that provide documentation.
+type Documented interface {
+	// Docs returns the documentation of the resource and its fields. Fields
+	// are keyed by their path, such as "Code.S3Bucket". The documentation of
+	// the resource itself has an empty key.
+	Docs() map[string]string
+}
+
+// A Schema describes the inputs and outputs of a resource type.
+type Schema struct {
+	Type    string
+	Doc     string
+	Inputs  []Attribute
+	Outputs []Attribute
+
+	// CloudFormationType is the corresponding CloudFormation resource type,
+	// such as AWS::Lambda::Function. Empty if the resource does not map to
+	// CloudFormation.
+	CloudFormationType string
+
+	// Source is set if the resource requires a source block.
+	Source bool
+}
+
+// An Attribute describes an input or output of a resource.
+type Attribute struct {
+	Name string
+
+	// Type is the type of the attribute, such as string, list(string) or
+	// map(number). Nested blocks have the type block, or list(block) if the
+	// block can be repeated.
+	Type string
+
+	Required bool
+	Doc      string
+
+	// Reference describes how an output is referenced in CloudFormation,
+	// either Ref or Fn::GetAtt with the attribute name. Empty if the output
+	// cannot be referenced in CloudFormation.
+	Reference string
+
+	// Attributes contains the attributes of a nested block.
+	Attributes []Attribute
+}
+
+// Schema returns the schema of a registered resource type.
+func (r *Registry) Schema(typename string) (*Schema, error) {
+	t, ok := r.types[typename]
+	if !ok {
+		return nil, fmt.Errorf("resource type %q not supported", typename)
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	v := reflect.New(t).Interface()
+	var docs map[string]string
+	if d, ok := v.(Documented); ok {
+		docs = d.Docs()
+	}
+	schema := &Schema{
+		Type:    typename,
+		Doc:     docs[""],
+		Inputs:  attributes(t, "input", "", docs),
+		Outputs: attributes(t, "output", "", docs),
+	}
+	if cf, ok := v.(interface{ CloudFormationType() string }); ok {
+		schema.CloudFormationType = cf.CloudFormationType()
+	}
+	if _, ok := v.(interface{ SetS3SourceCode(bucket, key string) }); ok {
+		schema.Source = true
+	}
+	return schema, nil
+}
+
+func attributes(t reflect.Type, tagName, prefix string, docs map[string]string) []Attribute {
+	var out []Attribute
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name, _ := parseTag(field.Tag.Get(tagName))
+		if name == "" {
+			continue
+		}
+		path := prefix + field.Name
+		attr := Attribute{
+			Name: name,
+			Doc:  docs[path],
+		}
+
+		ft := field.Type
+		ptr := ft.Kind() == reflect.Ptr
+		if ptr {
+			ft = ft.Elem()
+		}
+		switch {
+		case ft.Kind() == reflect.Struct && !isTime(ft):
+			attr.Type = "block"
+			attr.Required = !ptr
+			attr.Attributes = attributes(ft, tagName, path+".", docs)
+		case ft.Kind() == reflect.Slice && elem(ft).Kind() == reflect.Struct && !isTime(elem(ft)):
+			attr.Type = "list(block)"
+			attr.Required = parseIntOrZero(field.Tag.Get("min")) > 0
+			attr.Attributes = attributes(elem(ft), tagName, path+".", docs)
+		default:
+			attr.Type = typeName(ft)
+			attr.Required = !ptr && ft.Kind() != reflect.Slice && ft.Kind() != reflect.Map
+		}
+		if tagName == "output" {
+			attr.Required = false
+			attr.Reference = reference(field.Tag.Get("cloudformation"))
+		}
+		out = append(out, attr)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// reference returns how a field is referenced in CloudFormation, based on the
+// cloudformation struct tag.
+func reference(tag string) string {
+	parts := strings.Split(tag, ",")
+	if len(parts) < 2 {
+		return ""
+	}
+	switch parts[1] {
+	case "ref":
+		return "Ref"
+	case "att":
+		return "Fn::GetAtt " + parts[0]
+	}
+	return ""
+}
+
+func elem(t reflect.Type) reflect.Type {
+	et := t.Elem()
+	if et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+	return et
+}
+
+func isTime(t reflect.Type) bool {
+	return t.PkgPath() == "time" && t.Name() == "Time"
+}
+
+func typeName(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if isTime(t) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "list(" + typeName(t.Elem()) + ")"
+	case reflect.Map:
+		return "map(" + typeName(t.Elem()) + ")"
+	case reflect.Struct:
+		return "object"
+	default:
+		return "any"
+	}
+}
+
+// JSONSchema returns a JSON Schema describing the inputs of the resource.
+func (s *Schema) JSONSchema() map[string]interface{} {
+	props, required := jsonSchemaProperties(s.Inputs)
+	props["type"] = map[string]interface{}{
+		"const":       s.Type,
+		"description": "The type of the resource.",
+	}
+	props["source"] = map[string]interface{}{
+		"type":        "object",
+		"description": "The source code of the resource.",
+		"properties": map[string]interface{}{
+			"dir": map[string]interface{}{
+				"type":        "string",
+				"description": "Directory containing the source code, relative to the configuration file.",
+			},
+			"build": map[string]interface{}{
+				"type":        "string",
+				"description": "Build script to run on the source code.",
+			},
+			"include": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Glob patterns of files to include. All files are included if not set.",
+			},
+			"exclude": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Patterns of files to exclude, in .gitignore syntax.",
+			},
+			"tools": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Commands that print the versions of the tools used in the build, such as \"go version\".",
+			},
+			"env": map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": map[string]interface{}{"type": "string"},
+				"description":          "Environment variables to set for the build.",
+			},
+			"secret_env": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Names of environment variables to pass from the host environment to the build. The values are not logged.",
+			},
+			"builder": map[string]interface{}{
+				"type":        "string",
+				"enum":        source.Builders(),
+				"description": "Built-in builder to build the source code with, instead of a build script.",
+			},
+			"image": map[string]interface{}{
+				"type":        "string",
+				"description": "Container image to run the build script or builder in, using Docker or Podman.",
+			},
+		},
+		"required":             []string{"dir"},
+		"additionalProperties": false,
+	}
+	out := map[string]interface{}{
+		"$schema":              "http://json-schema.org/draft-07/schema#",
+		"title":                s.Type,
+		"type":                 "object",
+		"properties":           props,
+		"required":             append([]string{"type"}, required...),
+		"additionalProperties": false,
+	}
+	if s.Doc != "" {
+		out["description"] = s.Doc
+	}
+	return out
+}
+
+func jsonSchemaProperties(attrs []Attribute) (map[string]interface{}, []string) {
+	props := make(map[string]interface{}, len(attrs))
+	var required []string
+	for _, attr := range attrs {
+		var prop map[string]interface{}
+		switch attr.Type {
+		case "block":
+			prop = jsonSchemaObject(attr.Attributes)
+		case "list(block)":
+			prop = map[string]interface{}{
+				"type":  "array",
+				"items": jsonSchemaObject(attr.Attributes),
+			}
+		default:
+			prop = jsonSchemaType(attr.Type)
+		}
+		if attr.Doc != "" {
+			prop["description"] = attr.Doc
+		}
+		props[attr.Name] = prop
+		if attr.Required {
+			required = append(required, attr.Name)
+		}
+	}
+	return props, required
+}
+
+func jsonSchemaObject(attrs []Attribute) map[string]interface{} {
+	props, required := jsonSchemaProperties(attrs)
+	out := map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		out["required"] = required
+	}
+	return out
+}
+
+func jsonSchemaType(typ string) map[string]interface{} {
+	switch {
+	case typ == "string", typ == "number", typ == "object":
+		return map[string]interface{}{"type": typ}
+	case typ == "bool":
+		return map[string]interface{}{"type": "boolean"}
+	case strings.HasPrefix(typ, "list("):
+		return map[string]interface{}{
+			"type":  "array",
+			"items": jsonSchemaType(strings.TrimSuffix(strings.TrimPrefix(typ, "list("), ")")),
+		}
+	case strings.HasPrefix(typ, "map("):
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": jsonSchemaType(strings.TrimSuffix(strings.TrimPrefix(typ, "map("), ")")),
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
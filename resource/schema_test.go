@@ -0,0 +1,145 @@
+package resource
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type schemaTest struct {
+	Name   string            `input:"name"`
+	Memory *int              `input:"memory"`
+	Tags   map[string]string `input:"tags"`
+	Config struct {
+		Mode *string `input:"mode"`
+	} `input:"config"`
+	Rules []struct {
+		Values []string `input:"values"`
+	} `input:"rule" min:"1"`
+	ARN     string `cloudformation:"Arn,att" output:"arn"`
+	ID      string `cloudformation:"Id,ref" output:"id"`
+	Version string `output:"version"`
+}
+
+func (schemaTest) CloudFormationType() string { return "Test::Resource" }
+
+func (*schemaTest) SetS3SourceCode(bucket, key string) {}
+
+func (schemaTest) Docs() map[string]string {
+	return map[string]string{
+		"":            "Test resource.",
+		"Name":        "The name.",
+		"Config.Mode": "The mode.",
+		"ARN":         "The arn.",
+	}
+}
+
+func TestRegistry_Schema(t *testing.T) {
+	reg := &Registry{}
+	reg.Add("test", reflect.TypeOf(&schemaTest{}))
+
+	got, err := reg.Schema("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Schema{
+		Type: "test",
+		Doc:  "Test resource.",
+		Inputs: []Attribute{
+			{Name: "config", Type: "block", Required: true, Attributes: []Attribute{
+				{Name: "mode", Type: "string", Doc: "The mode."},
+			}},
+			{Name: "memory", Type: "number"},
+			{Name: "name", Type: "string", Required: true, Doc: "The name."},
+			{Name: "rule", Type: "list(block)", Required: true, Attributes: []Attribute{
+				{Name: "values", Type: "list(string)"},
+			}},
+			{Name: "tags", Type: "map(string)"},
+		},
+		Outputs: []Attribute{
+			{Name: "arn", Type: "string", Doc: "The arn.", Reference: "Fn::GetAtt Arn"},
+			{Name: "id", Type: "string", Reference: "Ref"},
+			{Name: "version", Type: "string"},
+		},
+		CloudFormationType: "Test::Resource",
+		Source:             true,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+
+	if _, err := reg.Schema("other"); err == nil {
+		t.Errorf("Want error for unsupported type")
+	}
+}
+
+func TestSchema_JSONSchema(t *testing.T) {
+	schema := &Schema{
+		Type: "test",
+		Inputs: []Attribute{
+			{Name: "name", Type: "string", Required: true, Doc: "The name."},
+			{Name: "tags", Type: "map(list(number))"},
+			{Name: "rule", Type: "list(block)", Attributes: []Attribute{
+				{Name: "enabled", Type: "bool", Required: true},
+			}},
+		},
+	}
+	got, err := json.Marshal(schema.JSONSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotMap map[string]interface{}
+	if err := json.Unmarshal(got, &gotMap); err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"title": "test",
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["type", "name"],
+		"properties": {
+			"type": {"const": "test", "description": "The type of the resource."},
+			"source": {
+				"type": "object",
+				"description": "The source code of the resource.",
+				"properties": {
+					"dir": {"type": "string", "description": "Directory containing the source code, relative to the configuration file."},
+					"build": {"type": "string", "description": "Build script to run on the source code."},
+					"include": {"type": "array", "items": {"type": "string"}, "description": "Glob patterns of files to include. All files are included if not set."},
+					"exclude": {"type": "array", "items": {"type": "string"}, "description": "Patterns of files to exclude, in .gitignore syntax."},
+					"tools": {"type": "array", "items": {"type": "string"}, "description": "Commands that print the versions of the tools used in the build, such as \"go version\"."},
+					"env": {"type": "object", "additionalProperties": {"type": "string"}, "description": "Environment variables to set for the build."},
+					"secret_env": {"type": "array", "items": {"type": "string"}, "description": "Names of environment variables to pass from the host environment to the build. The values are not logged."},
+					"builder": {"type": "string", "enum": ["go", "node", "python"], "description": "Built-in builder to build the source code with, instead of a build script."},
+					"image": {"type": "string", "description": "Container image to run the build script or builder in, using Docker or Podman."}
+				},
+				"required": ["dir"],
+				"additionalProperties": false
+			},
+			"name": {"type": "string", "description": "The name."},
+			"tags": {
+				"type": "object",
+				"additionalProperties": {"type": "array", "items": {"type": "number"}}
+			},
+			"rule": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {"enabled": {"type": "boolean"}},
+					"required": ["enabled"],
+					"additionalProperties": false
+				}
+			}
+		}
+	}`
+	var wantMap map[string]interface{}
+	if err := json.Unmarshal([]byte(want), &wantMap); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(gotMap, wantMap); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
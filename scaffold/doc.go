@@ -0,0 +1,2 @@
+// Package scaffold generates new projects from built-in templates.
+package scaffold
@@ -0,0 +1,108 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+
+	"golang.org/x/tools/txtar"
+)
+
+// A runtime is a template for a specific Lambda runtime.
+type runtime struct {
+	Runtime string
+	Handler string
+	Build   string
+	Builder string
+	Files   string // txtar archive of source files
+}
+
+// Options contains options for generating a project.
+type Options struct {
+	// Name is the name of the project. The name is used as the stack name and
+	// as a prefix for resource names.
+	Name string
+
+	// API adds an HTTP API that invokes the function.
+	API bool
+}
+
+// Templates returns the names of all available templates, sorted
+// alphabetically.
+func Templates() []string {
+	names := make([]string, 0, len(runtimes))
+	for name := range runtimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Write generates a project from the named template to dir. The names of the
+// written files are returned, relative to dir.
+//
+// Existing files are never overwritten. If any of the files already exist, an
+// error is returned and no files are written.
+func Write(dir, name string, opts Options) ([]string, error) {
+	rt, ok := runtimes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown template %q, available: %v", name, Templates())
+	}
+	if opts.Name == "" {
+		return nil, fmt.Errorf("project name not set")
+	}
+
+	data := struct {
+		runtime
+		Options
+	}{rt, opts}
+
+	files := append(txtar.Parse([]byte(common)).Files, txtar.Parse([]byte(rt.Files)).Files...)
+	out := make([]txtar.File, 0, len(files))
+	for _, f := range files {
+		tmpl, err := template.New(f.Name).Parse(string(f.Data))
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", f.Name, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("execute %s: %w", f.Name, err)
+		}
+		out = append(out, txtar.File{Name: f.Name, Data: buf.Bytes()})
+	}
+
+	for _, f := range out {
+		if _, err := os.Stat(filepath.Join(dir, f.Name)); err == nil {
+			return nil, fmt.Errorf("%s already exists", f.Name)
+		}
+	}
+
+	names := make([]string, len(out))
+	for i, f := range out {
+		filename := filepath.Join(dir, f.Name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return nil, err
+		}
+		if err := writeFile(filename, f.Data); err != nil {
+			return nil, err
+		}
+		names[i] = f.Name
+	}
+	return names, nil
+}
+
+// writeFile writes a new file. Fails if the file already exists.
+func writeFile(filename string, data []byte) error {
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
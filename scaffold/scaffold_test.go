@@ -0,0 +1,162 @@
+package scaffold
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/func/func/cloudformation"
+	"github.com/func/func/project"
+	"github.com/func/func/provider/aws/apigatewayv2"
+	"github.com/func/func/provider/aws/iam"
+	"github.com/func/func/provider/aws/lambda"
+	"github.com/func/func/resource"
+)
+
+func tempdir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Helper()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return dir
+}
+
+func TestWrite(t *testing.T) {
+	for _, name := range Templates() {
+		for _, api := range []bool{false, true} {
+			name, api := name, api
+			testname := name
+			if api {
+				testname += "_api"
+			}
+			t.Run(testname, func(t *testing.T) {
+				dir := tempdir(t)
+				files, err := Write(dir, name, Options{Name: "test", API: api})
+				if err != nil {
+					t.Fatalf("Write() err = %v", err)
+				}
+
+				for _, f := range files {
+					if filepath.Ext(f) != ".hcl" {
+						continue
+					}
+					src, err := ioutil.ReadFile(filepath.Join(dir, f))
+					if err != nil {
+						t.Fatal(err)
+					}
+					formatted, diags := resource.Format(src, f)
+					if diags.HasErrors() {
+						t.Fatalf("Format %s: %v", f, diags)
+					}
+					if !bytes.Equal(src, formatted) {
+						t.Errorf("%s is not formatted, got\n%s\nwant\n%s", f, src, formatted)
+					}
+				}
+
+				proj, diags := (&project.Loader{}).Find(dir)
+				if diags.HasErrors() {
+					t.Fatalf("Load project: %v", diags)
+				}
+				if proj == nil || proj.Name != "test" {
+					t.Errorf("Project not loaded, got %v", proj)
+				}
+
+				reg := &resource.Registry{}
+				iam.Register(reg)
+				lambda.Register(reg)
+				apigatewayv2.Register(reg)
+				loader := &resource.Loader{Registry: reg}
+				resources, diags := loader.LoadDir(dir)
+				if diags.HasErrors() {
+					t.Fatalf("Load resources: %v", diags)
+				}
+				want := 2
+				if api {
+					want = 4
+				}
+				if len(resources) != want {
+					t.Errorf("Got %d resources, want %d", len(resources), want)
+				}
+
+				sources := make(map[string]cloudformation.S3Location)
+				for _, res := range resources.WithSource() {
+					if _, err := res.SourceCode.Checksum(); err != nil {
+						t.Errorf("%s: Checksum() err = %v", res.Name, err)
+					}
+					sources[res.Name] = cloudformation.S3Location{Bucket: "bucket", Key: "key"}
+				}
+				if _, diags := cloudformation.Generate(resources, sources); diags.HasErrors() {
+					t.Errorf("Generate: %v", diags)
+				}
+			})
+		}
+	}
+}
+
+func TestWrite_goBuildChecksum(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skipf("Skipping test: %v", err)
+	}
+	// The build checksum must not depend on go.sum being updated by the go
+	// command.
+	goflags, ok := os.LookupEnv("GOFLAGS")
+	_ = os.Setenv("GOFLAGS", "")
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv("GOFLAGS", goflags)
+			return
+		}
+		_ = os.Unsetenv("GOFLAGS")
+	})
+
+	dir := tempdir(t)
+	if _, err := Write(dir, "go", Options{Name: "test"}); err != nil {
+		t.Fatalf("Write() err = %v", err)
+	}
+
+	reg := &resource.Registry{}
+	iam.Register(reg)
+	lambda.Register(reg)
+	loader := &resource.Loader{Registry: reg}
+	resources, diags := loader.LoadDir(dir)
+	if diags.HasErrors() {
+		t.Fatalf("Load resources: %v", diags)
+	}
+	for _, res := range resources.WithSource() {
+		if _, err := res.SourceCode.BuildChecksum(context.Background()); err != nil {
+			t.Errorf("%s: BuildChecksum() err = %v", res.Name, err)
+		}
+	}
+}
+
+func TestWrite_exists(t *testing.T) {
+	dir := tempdir(t)
+	if err := ioutil.WriteFile(filepath.Join(dir, project.Filename), []byte("existing"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Write(dir, "node", Options{Name: "test"}); err == nil {
+		t.Fatal("Want error")
+	}
+	got, err := ioutil.ReadFile(filepath.Join(dir, project.Filename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "existing" {
+		t.Errorf("File was overwritten")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "resources.hcl")); !os.IsNotExist(err) {
+		t.Errorf("Files written on error")
+	}
+}
+
+func TestWrite_unknown(t *testing.T) {
+	if _, err := Write(tempdir(t), "cobol", Options{Name: "test"}); err == nil {
+		t.Fatal("Want error")
+	}
+}
@@ -0,0 +1,136 @@
+package scaffold
+
+// common contains the files that are common to all templates.
+const common = `
+-- func.hcl --
+project {
+  name  = "{{.Name}}"
+  stack = "{{.Name}}"
+
+  # Bucket to upload source code to, required for deploying.
+  # source_bucket = ""
+}
+-- resources.hcl --
+resource "role" {
+  type = "aws:iam_role"
+  name = "{{.Name}}-role"
+
+  assume_role_policy = <<-EOF
+    {
+      "Version": "2012-10-17",
+      "Statement": [
+        {
+          "Effect": "Allow",
+          "Principal": { "Service": "lambda.amazonaws.com" },
+          "Action": "sts:AssumeRole"
+        }
+      ]
+    }
+  EOF
+}
+
+resource "handler" {
+  type = "aws:lambda_function"
+  source {
+{{- if .Build}}
+    dir   = "src"
+    build = <<-EOF
+{{.Build}}
+    EOF
+{{- else if .Builder}}
+    dir     = "src"
+    builder = "{{.Builder}}"
+{{- else}}
+    dir = "src"
+{{- end}}
+  }
+
+  name    = "{{.Name}}"
+  handler = "{{.Handler}}"
+  runtime = "{{.Runtime}}"
+  role    = role.arn
+}
+{{- if .API}}
+
+resource "api" {
+  type     = "aws:apigatewayv2_api"
+  name     = "{{.Name}}"
+  protocol = "HTTP"
+  target   = handler.arn
+}
+
+resource "api_permission" {
+  type         = "aws:lambda_permission"
+  action       = "lambda:InvokeFunction"
+  function     = handler.arn
+  principal    = "apigateway.amazonaws.com"
+  source_arn   = "arn:aws:execute-api:*:*:${api.id}/*"
+  statement_id = "api"
+}
+{{- end}}
+`
+
+var runtimes = map[string]runtime{
+	"go": {
+		Runtime: "provided.al2023",
+		Handler: "bootstrap",
+		Builder: "go",
+		Files: `
+-- src/go.mod --
+module {{.Name}}
+
+go 1.21
+
+require github.com/aws/aws-lambda-go v1.49.0
+-- src/go.sum --
+github.com/aws/aws-lambda-go v1.49.0 h1:z4VhTqkFZPM3xpEtTqWqRqsRH4TZBMJqTkRiBPYLqIQ=
+github.com/aws/aws-lambda-go v1.49.0/go.mod h1:dpMpZgvWx5vuQJfBt0zqBha60q7Dd7RfgJv23DymV8A=
+-- src/main.go --
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+func handler(ctx context.Context, event map[string]interface{}) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"statusCode": 200,
+		"body":       "Hello from {{.Name}}",
+	}, nil
+}
+
+func main() {
+	lambda.Start(handler)
+}
+`,
+	},
+	"node": {
+		Runtime: "nodejs20.x",
+		Handler: "index.handler",
+		Builder: "node",
+		Files: `
+-- src/index.js --
+exports.handler = async (event) => {
+  return {
+    statusCode: 200,
+    body: "Hello from {{.Name}}",
+  };
+};
+`,
+	},
+	"python": {
+		Runtime: "python3.12",
+		Handler: "handler.handler",
+		Builder: "python",
+		Files: `
+-- src/handler.py --
+def handler(event, context):
+    return {
+        "statusCode": 200,
+        "body": "Hello from {{.Name}}",
+    }
+`,
+	},
+}
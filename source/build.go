@@ -1,6 +1,7 @@
 package source
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -19,6 +20,19 @@ type BuildScript []BuildStep
 //
 // Lines starting with # are skipped.
 func ParseBuildScript(str string) (BuildScript, error) {
+	return parseBuildScript(str, true)
+}
+
+// ParseContainerBuildScript parses a build script to run in a container.
+//
+// It is like ParseBuildScript, except that executables are not checked, as
+// they are only available in the container.
+func ParseContainerBuildScript(str string) BuildScript {
+	script, _ := parseBuildScript(str, false)
+	return script
+}
+
+func parseBuildScript(str string, lookPath bool) (BuildScript, error) {
 	lines := strings.Split(str, "\n")
 	out := make(BuildScript, 0, len(lines))
 	for i, line := range lines {
@@ -31,6 +45,9 @@ func ParseBuildScript(str string) (BuildScript, error) {
 		}
 		parts := strings.Split(line, " ")
 		for _, part := range parts {
+			if !lookPath {
+				break
+			}
 			if strings.Contains(part, "=") {
 				continue
 			}
@@ -53,12 +70,36 @@ type BuildContext struct {
 	Env    map[string]string
 	Stdout io.Writer
 	Stderr io.Writer
+
+	// Secrets contains the names of variables in Env that are secret. The
+	// values of secrets are redacted from the output of the build.
+	Secrets []string
+
+	// CacheDir is the directory to cache dependencies in. If not set,
+	// dependencies are not cached.
+	CacheDir string
+
+	// Image is the container image to run build steps and builder commands
+	// in. If set, the build directory is mounted into a container. Otherwise
+	// commands are run on the host.
+	Image string
+}
+
+func (c *BuildContext) secretValues() [][]byte {
+	var out [][]byte
+	for _, name := range c.Secrets {
+		if v := c.Env[name]; v != "" {
+			out = append(out, []byte(v))
+		}
+	}
+	return out
 }
 
 // BuildStep is a step to execute in a build, typically one command.
 type BuildStep string
 
-// Exec executes a build step in a shell.
+// Exec executes a build step in a shell. If the build context has an image,
+// the shell is run in a container.
 //
 // Cancelling the context will terminate the running command.
 func (s BuildStep) Exec(ctx context.Context, buildContext *BuildContext) (err error) {
@@ -67,15 +108,54 @@ func (s BuildStep) Exec(ctx context.Context, buildContext *BuildContext) (err er
 		env = append(env, k+"="+v)
 	}
 
+	secrets := buildContext.secretValues()
 	cmd := exec.CommandContext(ctx, "sh", "-c", string(s))
+	if buildContext.Image != "" {
+		engine, err := ContainerEngine()
+		if err != nil {
+			return err
+		}
+		cmd = exec.CommandContext(ctx, engine, containerArgs(buildContext.Image, s, buildContext)...)
+	}
 	cmd.Env = env
 	cmd.Dir = buildContext.Dir
-	cmd.Stdout = buildContext.Stdout
-	cmd.Stderr = buildContext.Stderr
+	cmd.Stdout = redact(buildContext.Stdout, secrets)
+	cmd.Stderr = redact(buildContext.Stderr, secrets)
 
 	return cmd.Run()
 }
 
+// redacted replaces secret values in build output.
+const redacted = "***"
+
+// redactWriter replaces secrets in the data written to it.
+//
+// Secrets are only replaced within a single write. Build output is typically
+// written one or more lines at a time, so secrets are not expected to be split
+// across writes.
+type redactWriter struct {
+	w       io.Writer
+	secrets [][]byte
+}
+
+func redact(w io.Writer, secrets [][]byte) io.Writer {
+	if w == nil || len(secrets) == 0 {
+		return w
+	}
+	return &redactWriter{w: w, secrets: secrets}
+}
+
+func (r *redactWriter) Write(p []byte) (int, error) {
+	out := p
+	for _, s := range r.secrets {
+		out = bytes.ReplaceAll(out, s, []byte(redacted))
+	}
+	if _, err := r.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
 func (script BuildScript) String() string {
 	if len(script) == 0 {
 		return ""
@@ -79,6 +79,22 @@ func TestParseBuildScript(t *testing.T) {
 	}
 }
 
+func TestParseContainerBuildScript(t *testing.T) {
+	input := `
+        # executables are resolved in the container
+        thisfiledoesnotexist --xx
+        go build .
+    `
+	got := ParseContainerBuildScript(input)
+	want := BuildScript{
+		"thisfiledoesnotexist --xx",
+		"go build .",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
+
 func TestBuildStep_Exec(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -263,3 +279,29 @@ func tempDir(t *testing.T) string {
 	})
 	return dir
 }
+
+func TestBuildStep_Exec_secrets(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	buildContext := &BuildContext{
+		Dir: tempDir(t),
+		Env: map[string]string{
+			"TOKEN": "s3cr3t",
+			"GOOS":  "linux",
+		},
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+		Secrets: []string{"TOKEN"},
+	}
+
+	step := BuildStep("echo token=$TOKEN goos=$GOOS && >&2 echo $TOKEN")
+	if err := step.Exec(context.Background(), buildContext); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(stdout.String(), "token=*** goos=linux\n"); diff != "" {
+		t.Errorf("Stdout (-got +want)\n%s", diff)
+	}
+	if diff := cmp.Diff(stderr.String(), "***\n"); diff != "" {
+		t.Errorf("Stderr (-got +want)\n%s", diff)
+	}
+}
@@ -0,0 +1,65 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// A Target describes the environment the source code is built for.
+type Target struct {
+	// Runtime is the Lambda runtime, such as go1.x or provided.al2.
+	Runtime string
+
+	// Handler is the function handler.
+	Handler string
+
+	// Arch is the instruction set architecture, x86_64 or arm64. If not set,
+	// x86_64 is used.
+	Arch string
+}
+
+// A Builder builds source code into artifacts that can be deployed.
+type Builder interface {
+	// Name returns the name of the builder, as used in the source block.
+	Name() string
+
+	// Inputs returns the source files that affect the output of the build.
+	// Only the inputs are included in the build checksum. Commands run to
+	// determine the inputs are run in the build context, as in Build; the
+	// build context has no build directory.
+	Inputs(ctx context.Context, files *FileList, target Target, buildContext *BuildContext) (*FileList, error)
+
+	// Tools returns commands that print the versions of the tools used in the
+	// build.
+	Tools() []string
+
+	// Build builds the source files. The artifacts are written to the
+	// directory set in the build context.
+	Build(ctx context.Context, files *FileList, target Target, buildContext *BuildContext) error
+}
+
+var builders = map[string]func() Builder{
+	"go":     func() Builder { return &GoBuilder{} },
+	"node":   func() Builder { return &NodeBuilder{} },
+	"python": func() Builder { return &PythonBuilder{} },
+}
+
+// NewBuilder returns the builder with the given name.
+func NewBuilder(name string) (Builder, error) {
+	newBuilder, ok := builders[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported builder %q", name)
+	}
+	return newBuilder(), nil
+}
+
+// Builders returns the names of the supported builders.
+func Builders() []string {
+	out := make([]string, 0, len(builders))
+	for name := range builders {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,160 @@
+package source
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// A Cache stores source code archives on the local disk. Archives are stored
+// by key, which should be derived from the build checksum, so an archive
+// never has to be built twice.
+//
+// Dependencies installed by builders are stored in the deps directory of the
+// same cache directory.
+type Cache struct {
+	Dir string
+}
+
+// Get returns the path to a cached archive. False is returned if the archive
+// is not cached.
+//
+// The modification time of the archive is updated, so that recently used
+// archives are kept when the cache is pruned.
+func (c *Cache) Get(key string) (string, bool) {
+	path := filepath.Join(c.Dir, key)
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return path, true
+}
+
+// Put adds an archive to the cache and returns the path to it. The archive is
+// written with the write function, for example FileList.Zip.
+//
+// The archive is only added if write succeeds, concurrent readers never see a
+// partially written archive.
+func (c *Cache) Put(key string, write func(w io.Writer) error) (string, error) {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return "", err
+	}
+	f, err := ioutil.TempFile(c.Dir, ".tmp-"+key)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	path := filepath.Join(c.Dir, key)
+	if err := os.Rename(f.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// PruneResult contains the result of pruning the cache.
+type PruneResult struct {
+	// Removed is the number of removed archives and dependency directories,
+	// including temporary files left behind by interrupted builds.
+	Removed int
+
+	// Legacy is the number of removed archives stored under keys from
+	// version 1 checksums, included in Removed.
+	Legacy int
+
+	// Size is the total size of the removed files, in bytes.
+	Size int64
+}
+
+// Prune removes archives and dependencies that have not been used within
+// maxAge. If maxAge is 0, everything is removed. Temporary files left behind
+// by interrupted builds are removed by age as well.
+//
+// Archives stored under version 1 checksum keys are always removed, as
+// checksums never match them; see ChecksumVersion.
+func (c *Cache) Prune(maxAge time.Duration) (PruneResult, error) {
+	var res PruneResult
+	deadline := time.Now().Add(-maxAge)
+
+	var entries []string
+	legacy := make(map[string]bool)
+	for _, dir := range []string{c.Dir, filepath.Join(c.Dir, "deps")} {
+		infos, err := ioutil.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return res, err
+		}
+		for _, info := range infos {
+			if dir == c.Dir && info.IsDir() {
+				// Only archives are stored in the root.
+				continue
+			}
+			path := filepath.Join(dir, info.Name())
+			if dir == c.Dir && legacyKey(info.Name()) {
+				legacy[path] = true
+				entries = append(entries, path)
+				continue
+			}
+			if maxAge > 0 && info.ModTime().After(deadline) {
+				continue
+			}
+			entries = append(entries, path)
+		}
+	}
+
+	for _, path := range entries {
+		size, err := treeSize(path)
+		if err != nil {
+			return res, err
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return res, err
+		}
+		res.Removed++
+		if legacy[path] {
+			res.Legacy++
+		}
+		res.Size += size
+	}
+	return res, nil
+}
+
+// legacyKey reports whether the archive is stored under a version 1 key, a
+// hex encoded sha256 checksum without a version prefix.
+func legacyKey(name string) bool {
+	sum := strings.TrimSuffix(name, ".zip")
+	if sum == name || len(sum) != 2*sha256.Size {
+		return false
+	}
+	_, err := hex.DecodeString(sum)
+	return err == nil
+}
+
+// treeSize returns the total size of the files in path.
+func treeSize(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,106 @@
+package source
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCache_GetPut(t *testing.T) {
+	cache := &Cache{Dir: filepath.Join(tempdir(t), "cache")}
+
+	if _, ok := cache.Get("abc.zip"); ok {
+		t.Fatalf("Get() returned archive from empty cache")
+	}
+
+	_, err := cache.Put("abc.zip", func(w io.Writer) error {
+		_, _ = w.Write([]byte("partial"))
+		return errors.New("zip failed")
+	})
+	if err == nil {
+		t.Fatalf("Put() did not return error")
+	}
+	if _, ok := cache.Get("abc.zip"); ok {
+		t.Fatalf("Failed archive was added to cache")
+	}
+
+	path, err := cache.Put("abc.zip", func(w io.Writer) error {
+		_, err := w.Write([]byte("archive"))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := cache.Get("abc.zip")
+	if !ok {
+		t.Fatalf("Archive not cached")
+	}
+	if got != path {
+		t.Errorf("Get() = %q, want %q", got, path)
+	}
+	data, err := ioutil.ReadFile(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "archive" {
+		t.Errorf("Content = %q, want %q", data, "archive")
+	}
+}
+
+func TestCache_Prune(t *testing.T) {
+	dir := tempdir(t)
+	writeTxtar(t, dir, `
+-- old.zip --
+old
+-- new.zip --
+new
+-- deps/go-old/dep.txt --
+dep
+-- deps/go-new/dep.txt --
+dep
+-- .tmp-old.zip123 --
+tmp
+-- deps/.tmp-go-old123/dep.txt --
+tmp
+-- 0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef.zip --
+v1
+`)
+	old := time.Now().Add(-48 * time.Hour)
+	for _, name := range []string{"old.zip", "deps/go-old", ".tmp-old.zip123", "deps/.tmp-go-old123"} {
+		if err := os.Chtimes(filepath.Join(dir, name), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cache := &Cache{Dir: dir}
+
+	res, err := cache.Prune(24 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := PruneResult{Removed: 5, Legacy: 1, Size: 19}
+	if res != want {
+		t.Errorf("Prune() = %+v, want %+v", res, want)
+	}
+	if _, ok := cache.Get("0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef.zip"); ok {
+		t.Errorf("Version 1 archive not removed")
+	}
+	if _, ok := cache.Get("old.zip"); ok {
+		t.Errorf("Old archive not removed")
+	}
+	if _, ok := cache.Get("new.zip"); !ok {
+		t.Errorf("New archive removed")
+	}
+
+	res, err = cache.Prune(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = PruneResult{Removed: 2, Size: 8}
+	if res != want {
+		t.Errorf("Prune(0) = %+v, want %+v", res, want)
+	}
+}
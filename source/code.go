@@ -1,39 +1,253 @@
 package source
 
 import (
+	"bytes"
+	"context"
 	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
+	"fmt"
+	"hash"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
 )
 
+// ChecksumVersion is the version of the checksum scheme. It is included as a
+// prefix in checksums, so checksums computed with different schemes never
+// match.
+//
+// Version 1 checksums have no prefix and only include file contents and the
+// build script. Archives stored under version 1 keys are not migrated; as the
+// keys never match, the source code is built and uploaded again. The local
+// cache removes version 1 archives when it is pruned.
+const ChecksumVersion = "v2"
+
 // Code holds the source code defined for a resource.
 type Code struct {
 	Files *FileList
 	Build BuildScript
+
+	// Builder is the built-in builder to build the source code with. If set,
+	// only the inputs reported by the builder are included in the build
+	// checksum.
+	Builder Builder
+
+	// Target is the environment the source code is built for.
+	Target Target
+
+	// Image is the container image to run the build script or builder in.
+	// If not set, the build runs on the host.
+	Image string
+
+	// Env contains environment variables to set for the build.
+	Env map[string]string
+
+	// SecretEnv contains the names of environment variables that are read
+	// from the host environment and set for the build. Only the names are
+	// included in the checksum.
+	SecretEnv []string
+
+	// Tools contains commands that print versions of the tools used in the
+	// build, such as "go version". The output of the commands is included in
+	// the build checksum, so the source code is built again when a tool is
+	// upgraded.
+	Tools []string
 }
 
-// Checksum computes the checksum of the source code.
+// Checksum computes the checksum of the source code. The checksum is used as
+// the key of the source code archive.
 //
-// The checksum is based on the contents of all the source files and the build
-// script. Files are processed in lexicographical order. File names do not
-// affect the result, except possibly changing the order the files are
-// processed.
+// The checksum is based on the names, modes and contents of all the source
+// files, the build script and the build configuration: the builder and
+// target, the image, environment variables, the names of secret environment
+// variables and the tool version commands. Files are processed in
+// lexicographical order. Only the executable bit of the file mode is
+// considered.
+//
+// No commands are run, so the checksum can be computed offline, for example
+// when validating. Inputs that are only known by running tools are included
+// in the build checksum instead.
+//
+// The returned checksum is prefixed with the checksum version.
 func (c *Code) Checksum() (string, error) {
 	sha := sha256.New()
-	for _, f := range c.Files.Absolute() {
-		f, err := os.Open(f)
+	writeField(sha, []byte(ChecksumVersion))
+	if err := writeFiles(sha, c.Files); err != nil {
+		return "", err
+	}
+	c.writeConfig(sha)
+	for _, tool := range c.tools() {
+		writeField(sha, []byte(tool))
+	}
+	return ChecksumVersion + "-" + hex.EncodeToString(sha.Sum(nil)), nil
+}
+
+// BuildChecksum computes the checksum of the build. The checksum is used as
+// the key of built archives in the local cache, so an archive is built again
+// when a tool or the image changes.
+//
+// The build checksum is based on the same build configuration as Checksum.
+// If a builder is set, only the inputs reported by the builder are included,
+// instead of all source files. The output of the tool version commands is
+// included and, if an image is set, the image digest. The builder and the
+// tool version commands run with the build environment, in the image if set.
+// The image is pulled if it does not exist locally, cancelling the context
+// stops the pull.
+//
+// The build checksum should only be computed when the source code is built.
+func (c *Code) BuildChecksum(ctx context.Context) (string, error) {
+	sha := sha256.New()
+	writeField(sha, []byte(ChecksumVersion))
+
+	tools := c.tools()
+	var buildContext *BuildContext
+	if c.Builder != nil || len(tools) > 0 {
+		env, err := c.BuildEnv()
 		if err != nil {
 			return "", err
 		}
-		if _, err := io.Copy(sha, f); err != nil {
-			_ = f.Close()
-			return "", err
+		buildContext = &BuildContext{
+			Env:     env,
+			Secrets: c.SecretEnv,
+			Image:   c.Image,
+		}
+	}
+
+	list := c.Files
+	if c.Builder != nil {
+		inputs, err := c.Builder.Inputs(ctx, c.Files, c.Target, buildContext)
+		if err != nil {
+			return "", fmt.Errorf("get %s build inputs: %w", c.Builder.Name(), err)
 		}
-		_ = f.Close()
+		list = inputs
 	}
-	if _, err := sha.Write([]byte(c.Build.String())); err != nil {
+	if err := writeFiles(sha, list); err != nil {
 		return "", err
 	}
-	return hex.EncodeToString(sha.Sum(nil)), nil
+	c.writeConfig(sha)
+
+	if c.Image != "" {
+		digest, err := ImageDigest(ctx, c.Image)
+		if err != nil {
+			return "", fmt.Errorf("get image digest: %w", err)
+		}
+		writeField(sha, []byte(digest))
+	}
+
+	for _, tool := range tools {
+		cmd, err := command(ctx, buildContext, c.Files.Root, nil, "sh", "-c", tool)
+		if err != nil {
+			return "", err
+		}
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("get tool version: %s: %w", tool, err)
+		}
+		writeField(sha, []byte(tool))
+		writeField(sha, bytes.TrimSpace(out))
+	}
+
+	return ChecksumVersion + "-" + hex.EncodeToString(sha.Sum(nil)), nil
+}
+
+// writeConfig writes the build configuration to the hash.
+func (c *Code) writeConfig(h hash.Hash) {
+	if c.Builder != nil {
+		writeField(h, []byte(c.Builder.Name()))
+		writeField(h, []byte(c.Target.Runtime))
+		writeField(h, []byte(c.Target.Handler))
+		writeField(h, []byte(c.Target.Arch))
+	}
+
+	writeField(h, []byte(c.Build.String()))
+	writeField(h, []byte(c.Image))
+
+	keys := make([]string, 0, len(c.Env))
+	for k := range c.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeField(h, []byte(k+"="+c.Env[k]))
+	}
+
+	secrets := make([]string, len(c.SecretEnv))
+	copy(secrets, c.SecretEnv)
+	sort.Strings(secrets)
+	for _, name := range secrets {
+		writeField(h, []byte(name))
+	}
+}
+
+// tools returns the tool version commands of the builder and the source
+// code.
+func (c *Code) tools() []string {
+	if c.Builder == nil {
+		return c.Tools
+	}
+	return append(c.Builder.Tools(), c.Tools...)
+}
+
+// BuildEnv returns the environment variables to set for the build, including
+// secrets read from the host environment. An error is returned if a secret is
+// not set.
+func (c *Code) BuildEnv() (map[string]string, error) {
+	env := make(map[string]string, len(c.Env)+len(c.SecretEnv))
+	for k, v := range c.Env {
+		env[k] = v
+	}
+	for _, name := range c.SecretEnv {
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("secret environment variable %s is not set", name)
+		}
+		env[name] = v
+	}
+	return env, nil
+}
+
+// writeFiles writes the files in the list to the hash, in lexicographical
+// order.
+func writeFiles(h hash.Hash, list *FileList) error {
+	files := make([]string, len(list.Files))
+	copy(files, list.Files)
+	sort.Strings(files)
+	for _, name := range files {
+		if err := writeFile(h, list.Root, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(h hash.Hash, root, name string) error {
+	f, err := os.Open(filepath.Join(root, name))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	writeField(h, []byte(filepath.ToSlash(name)))
+	writeField(h, []byte(archiveMode(info.Mode()).String()))
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], uint64(info.Size()))
+	_, _ = h.Write(size[:])
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	return nil
+}
+
+// writeField writes a length prefixed field to the hash, so that adjacent
+// fields cannot be confused.
+func writeField(h hash.Hash, data []byte) {
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], uint64(len(data)))
+	_, _ = h.Write(size[:])
+	_, _ = h.Write(data)
 }
@@ -1,7 +1,13 @@
 package source
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestCode_Checksum(t *testing.T) {
@@ -14,6 +20,27 @@ package main
 func main() {}
 `)
 
+	renamed := tempdir(t)
+	writeTxtar(t, renamed, `
+-- go.mod --
+module github.com/test/test
+-- app.go --
+package main
+func main() {}
+`)
+
+	executable := tempdir(t)
+	writeTxtar(t, executable, `
+-- go.mod --
+module github.com/test/test
+-- main.go --
+package main
+func main() {}
+`)
+	if err := os.Chmod(filepath.Join(executable, "main.go"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
 	var (
 		code1 = &Code{
 			Files: &FileList{Root: dir, Files: []string{"go.mod", "main.go"}},
@@ -33,6 +60,31 @@ func main() {}
 				"go build -ldflags \"-w -s\" .",
 			},
 		}
+		code5 = &Code{
+			Files: &FileList{Root: renamed, Files: []string{"go.mod", "app.go"}},
+		}
+		code6 = &Code{
+			Files: &FileList{Root: executable, Files: []string{"go.mod", "main.go"}},
+		}
+		code7 = &Code{
+			Files: &FileList{Root: dir, Files: []string{"main.go", "go.mod"}},
+		}
+		code8 = &Code{
+			Files: &FileList{Root: dir, Files: []string{"go.mod", "main.go"}},
+			Env:   map[string]string{"GOOS": "linux"},
+		}
+		code9 = &Code{
+			Files: &FileList{Root: dir, Files: []string{"go.mod", "main.go"}},
+			Tools: []string{"echo go1.14"},
+		}
+		code11 = &Code{
+			Files:     &FileList{Root: dir, Files: []string{"go.mod", "main.go"}},
+			SecretEnv: []string{"TOKEN"},
+		}
+		code10 = &Code{
+			Files: &FileList{Root: dir, Files: []string{"go.mod", "main.go"}},
+			Tools: []string{"echo go1.15"},
+		}
 	)
 
 	tests := []struct {
@@ -44,6 +96,13 @@ func main() {}
 		{name: "DiffFiles", a: code1, b: code2, wantEqual: false},
 		{name: "WithBuild", a: code1, b: code3, wantEqual: false},
 		{name: "DiffBuild", a: code3, b: code4, wantEqual: false},
+		{name: "Renamed", a: code1, b: code5, wantEqual: false},
+		{name: "Executable", a: code1, b: code6, wantEqual: false},
+		{name: "Order", a: code1, b: code7, wantEqual: true},
+		{name: "Env", a: code1, b: code8, wantEqual: false},
+		{name: "Tools", a: code1, b: code9, wantEqual: false},
+		{name: "DiffTools", a: code9, b: code10, wantEqual: false},
+		{name: "SecretEnv", a: code1, b: code11, wantEqual: false},
 	}
 
 	for _, tc := range tests {
@@ -63,3 +122,137 @@ func main() {}
 		})
 	}
 }
+
+func TestCode_Checksum_version(t *testing.T) {
+	dir := tempdir(t)
+	writeTxtar(t, dir, `
+-- main.go --
+package main
+`)
+	code := &Code{Files: &FileList{Root: dir, Files: []string{"main.go"}}}
+	sum, err := code.Checksum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sum, ChecksumVersion+"-") {
+		t.Errorf("Checksum %q does not have version prefix", sum)
+	}
+}
+
+func TestCode_Checksum_offline(t *testing.T) {
+	dir := tempdir(t)
+	code := &Code{
+		Files: &FileList{Root: dir},
+		Tools: []string{"exit 1"},
+		Image: "func-test-image-that-does-not-exist",
+	}
+	if _, err := code.Checksum(); err != nil {
+		t.Errorf("Checksum() err = %v, tools and image must not be probed", err)
+	}
+}
+
+func TestCode_BuildChecksum(t *testing.T) {
+	dir := tempdir(t)
+	writeTxtar(t, dir, `
+-- main.go --
+package main
+-- version.txt --
+1.0
+`)
+	code := &Code{
+		Files: &FileList{Root: dir, Files: []string{"main.go"}},
+		Tools: []string{"cat version.txt"},
+	}
+
+	sum1, err := code.BuildChecksum(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sum1, ChecksumVersion+"-") {
+		t.Errorf("Build checksum %q does not have version prefix", sum1)
+	}
+	writeTxtar(t, dir, `
+-- version.txt --
+1.1
+`)
+	sum2, err := code.BuildChecksum(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum1 == sum2 {
+		t.Errorf("Build checksum did not change when tool version changed")
+	}
+
+	offline, err := code.Checksum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offline == sum2 {
+		t.Errorf("Checksum equals build checksum, tool version not included")
+	}
+}
+
+func TestCode_BuildChecksum_toolError(t *testing.T) {
+	dir := tempdir(t)
+	code := &Code{
+		Files: &FileList{Root: dir},
+		Tools: []string{"exit 1"},
+	}
+	if _, err := code.BuildChecksum(context.Background()); err == nil {
+		t.Errorf("Want error")
+	}
+}
+
+func TestCode_Checksum_secretValue(t *testing.T) {
+	dir := tempdir(t)
+	code := &Code{
+		Files:     &FileList{Root: dir},
+		SecretEnv: []string{"FUNC_TEST_SECRET"},
+	}
+
+	setenv(t, "FUNC_TEST_SECRET", "foo")
+	sum1, err := code.Checksum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	setenv(t, "FUNC_TEST_SECRET", "bar")
+	sum2, err := code.Checksum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum1 != sum2 {
+		t.Errorf("Checksum changed when secret value changed")
+	}
+}
+
+func TestCode_BuildEnv(t *testing.T) {
+	code := &Code{
+		Env:       map[string]string{"GOOS": "linux"},
+		SecretEnv: []string{"FUNC_TEST_SECRET"},
+	}
+
+	if _, err := code.BuildEnv(); err == nil {
+		t.Errorf("Want error when secret is not set")
+	}
+
+	setenv(t, "FUNC_TEST_SECRET", "s3cr3t")
+	got, err := code.BuildEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"GOOS":             "linux",
+		"FUNC_TEST_SECRET": "s3cr3t",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
+
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Unsetenv(key) })
+}
@@ -7,7 +7,8 @@ import (
 
 // A Filter filters source files prior to adding them to a file list.
 //
-// Filter is called with paths relative to the root.
+// Filter is called with paths relative to the root. Directories are passed
+// with a trailing separator.
 type Filter func(path string) bool
 
 // ExcludeFile excludes a file from the source file list.
@@ -41,8 +42,12 @@ func Collect(dir string, filters ...Filter) (*FileList, error) {
 			return err
 		}
 		rel, _ := filepath.Rel(dir, path)
+		name := rel
+		if info.IsDir() {
+			name += string(filepath.Separator)
+		}
 		for _, filter := range filters {
-			if !filter(rel) {
+			if !filter(name) {
 				if info.IsDir() {
 					// Filter matched dir, skip contents
 					return filepath.SkipDir
@@ -97,6 +97,46 @@ package main
 				"source/main.go",
 			},
 		},
+		{
+			name: "Exclude",
+			files: `
+-- index.js --
+exports
+-- index.test.js --
+test
+-- node_modules/lodash/index.js --
+lodash
+-- node_modules/.cache/x --
+x
+-- fixtures/data.json --
+{}
+`,
+			filters: []Filter{
+				mustFilter(Exclude("*.test.js", "fixtures/", "node_modules/.cache/")),
+			},
+			wantFiles: []string{
+				"index.js",
+				"node_modules/lodash/index.js",
+			},
+		},
+		{
+			name: "Include",
+			files: `
+-- handler.py --
+def handler(): pass
+-- requirements.txt --
+boto3
+-- lib/util.py --
+pass
+`,
+			filters: []Filter{
+				mustFilter(Include("*.py")),
+			},
+			wantFiles: []string{
+				"handler.py",
+				"lib/util.py",
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -114,3 +154,10 @@ package main
 		})
 	}
 }
+
+func mustFilter(f Filter, err error) Filter {
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
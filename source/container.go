@@ -0,0 +1,151 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// containerWorkDir is the directory in the container the build directory is
+// mounted to.
+const containerWorkDir = "/var/task"
+
+// containerEngines are the supported container engines, in order of
+// preference.
+var containerEngines = []string{"docker", "podman"}
+
+// ContainerEngine returns the container engine to run builds with. Docker is
+// used if available, otherwise Podman.
+func ContainerEngine() (string, error) {
+	for _, name := range containerEngines {
+		if _, err := exec.LookPath(name); err == nil {
+			return name, nil
+		}
+	}
+	return "", errors.New("no container engine found, install docker or podman")
+}
+
+// ImageDigest returns the ID of a container image. The image is pulled if it
+// does not exist locally.
+func ImageDigest(ctx context.Context, image string) (string, error) {
+	engine, err := ContainerEngine()
+	if err != nil {
+		return "", err
+	}
+	inspect := func() (string, error) {
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, engine, "image", "inspect", "--format", "{{.Id}}", image)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("%s image inspect: %w: %s", engine, err, strings.TrimSpace(stderr.String()))
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+	if id, err := inspect(); err == nil {
+		return id, nil
+	}
+	var stderr bytes.Buffer
+	pull := exec.CommandContext(ctx, engine, "pull", image)
+	pull.Stderr = &stderr
+	if err := pull.Run(); err != nil {
+		return "", fmt.Errorf("%s pull: %w: %s", engine, err, strings.TrimSpace(stderr.String()))
+	}
+	return inspect()
+}
+
+// containerArgs returns the arguments to the container engine for running
+// the build step in the image. The build directory is mounted as the working
+// directory. The container runs as the current user, see userArgs.
+//
+// Environment variables are passed by name only, so their values, including
+// secrets, are not visible in the command line. The values are read from the
+// environment of the engine process.
+func containerArgs(image string, step BuildStep, buildContext *BuildContext) []string {
+	args := []string{
+		"run", "--rm",
+		"--volume", buildContext.Dir + ":" + containerWorkDir,
+		"--workdir", containerWorkDir,
+	}
+	args = append(args, userArgs()...)
+	keys := make([]string, 0, len(buildContext.Env))
+	for k := range buildContext.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "--env", k)
+	}
+	return append(args, image, "sh", "-c", string(step))
+}
+
+// commandArgs returns the arguments to the container engine for running a
+// builder command in the image.
+//
+// Builders refer to files with host paths, so directories are mounted to the
+// same paths in the container: the working directory, the build directory,
+// the cache directory and any directories in args. Environment variables are
+// passed by name only, as in containerArgs; env sets additional variables.
+// The container runs as the current user, see userArgs.
+func commandArgs(image string, buildContext *BuildContext, dir string, env []string, name string, args ...string) []string {
+	out := []string{"run", "--rm"}
+	mounted := make(map[string]bool)
+	mount := func(path string) {
+		if path == "" || mounted[path] {
+			return
+		}
+		mounted[path] = true
+		out = append(out, "--volume", path+":"+path)
+	}
+	mount(dir)
+	mount(buildContext.Dir)
+	mount(buildContext.CacheDir)
+	for _, arg := range args {
+		if !filepath.IsAbs(arg) {
+			continue
+		}
+		if info, err := os.Stat(arg); err == nil && info.IsDir() {
+			mount(arg)
+		}
+	}
+	out = append(out, "--workdir", dir)
+	out = append(out, userArgs()...)
+
+	keys := make([]string, 0, len(buildContext.Env))
+	for k := range buildContext.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, kv := range env {
+		keys = append(keys, strings.SplitN(kv, "=", 2)[0])
+	}
+	for _, k := range keys {
+		out = append(out, "--env", k)
+	}
+	out = append(out, image, name)
+	return append(out, args...)
+}
+
+// userArgs returns the arguments to run a container as the current user, so
+// that files written to mounted directories are owned by the user instead of
+// root. As the user does not exist in the image, HOME is set to a writable
+// directory for tools that cache files in it. Variables set in the build
+// environment are passed after, so HOME can be overridden.
+//
+// No arguments are returned on platforms without user ids.
+func userArgs() []string {
+	uid, gid := os.Getuid(), os.Getgid()
+	if uid < 0 {
+		return nil
+	}
+	return []string{
+		"--user", fmt.Sprintf("%d:%d", uid, gid),
+		"--env", "HOME=/tmp",
+	}
+}
@@ -0,0 +1,61 @@
+package source
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestContainerArgs(t *testing.T) {
+	buildContext := &BuildContext{
+		Dir: "/tmp/build",
+		Env: map[string]string{
+			"TOKEN": "secret",
+			"FOO":   "bar",
+		},
+	}
+	got := containerArgs("golang:1.15", "go build -o main .", buildContext)
+	want := []string{
+		"run", "--rm",
+		"--volume", "/tmp/build:/var/task",
+		"--workdir", "/var/task",
+		"--user", fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()),
+		"--env", "HOME=/tmp",
+		"--env", "FOO",
+		"--env", "TOKEN",
+		"golang:1.15", "sh", "-c", "go build -o main .",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
+
+func TestCommandArgs(t *testing.T) {
+	deps := tempdir(t)
+	buildContext := &BuildContext{
+		Dir:      "/tmp/build",
+		CacheDir: "/tmp/cache",
+		Env: map[string]string{
+			"TOKEN": "secret",
+		},
+	}
+	got := commandArgs("python:3.8", buildContext, "/src", []string{"GOOS=linux"}, "pip", "install", "-t", deps, "/not/exists")
+	want := []string{
+		"run", "--rm",
+		"--volume", "/src:/src",
+		"--volume", "/tmp/build:/tmp/build",
+		"--volume", "/tmp/cache:/tmp/cache",
+		"--volume", deps + ":" + deps,
+		"--workdir", "/src",
+		"--user", fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()),
+		"--env", "HOME=/tmp",
+		"--env", "TOKEN",
+		"--env", "GOOS",
+		"python:3.8", "pip", "install", "-t", deps, "/not/exists",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
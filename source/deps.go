@@ -0,0 +1,187 @@
+package source
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// depsKey computes a cache key for dependencies installed from the given
+// files, such as a lockfile. Files that do not exist are skipped.
+//
+// Dependencies installed in a container image are cached separately from
+// dependencies installed on the host.
+func depsKey(builder string, target Target, image, root string, files ...string) (string, error) {
+	sha := sha256.New()
+	writeField(sha, []byte(builder))
+	writeField(sha, []byte(target.Runtime))
+	writeField(sha, []byte(target.Arch))
+	writeField(sha, []byte(image))
+	for _, name := range files {
+		data, err := ioutil.ReadFile(filepath.Join(root, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		writeField(sha, []byte(name))
+		writeField(sha, data)
+	}
+	return builder + "-" + hex.EncodeToString(sha.Sum(nil)), nil
+}
+
+// installDeps returns a directory containing dependencies, installed with the
+// install function.
+//
+// If the build context has a cache directory, the dependencies are cached by
+// key and only installed if they are not already cached. Otherwise they are
+// installed to a temporary directory. The returned function removes any
+// temporary files and must always be called.
+func installDeps(buildContext *BuildContext, key string, install func(dir string) error) (string, func(), error) {
+	noop := func() {}
+
+	if buildContext.CacheDir == "" {
+		dir, err := ioutil.TempDir("", "func-deps")
+		if err != nil {
+			return "", noop, err
+		}
+		cleanup := func() { _ = os.RemoveAll(dir) }
+		if err := install(dir); err != nil {
+			cleanup()
+			return "", noop, err
+		}
+		return dir, cleanup, nil
+	}
+
+	depsDir := filepath.Join(buildContext.CacheDir, "deps")
+	dir := filepath.Join(depsDir, key)
+	if _, err := os.Stat(dir); err == nil {
+		// Keep recently used dependencies when the cache is pruned.
+		now := time.Now()
+		if err := os.Chtimes(dir, now, now); err != nil {
+			return "", noop, err
+		}
+		if buildContext.Stderr != nil {
+			fmt.Fprintf(buildContext.Stderr, "Using cached dependencies %s\n", key)
+		}
+		return dir, noop, nil
+	}
+
+	if err := os.MkdirAll(depsDir, 0755); err != nil {
+		return "", noop, err
+	}
+	tmp, err := ioutil.TempDir(depsDir, ".tmp-"+key)
+	if err != nil {
+		return "", noop, err
+	}
+	if err := install(tmp); err != nil {
+		_ = os.RemoveAll(tmp)
+		return "", noop, err
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		_ = os.RemoveAll(tmp)
+		if _, statErr := os.Stat(dir); statErr == nil {
+			// Installed concurrently by another build.
+			return dir, noop, nil
+		}
+		return "", noop, err
+	}
+	return dir, noop, nil
+}
+
+// copyTree copies all files in the src directory to dst. File modes are
+// retained.
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		}
+		if err := copyFile(src, rel, dst); err != nil {
+			return err
+		}
+		return os.Chmod(target, info.Mode().Perm())
+	})
+}
+
+// command creates a command to run in a build. The command is printed to
+// stderr. The environment of the build context is set, followed by env.
+//
+// If the build context has an image, the command runs in a container.
+func command(ctx context.Context, buildContext *BuildContext, dir string, env []string, name string, args ...string) (*exec.Cmd, error) {
+	if buildContext.Stderr != nil {
+		line := append(append([]string{}, env...), name)
+		line = append(line, args...)
+		fmt.Fprintln(buildContext.Stderr, strings.Join(line, " "))
+	}
+
+	cmdEnv := os.Environ()
+	for k, v := range buildContext.Env {
+		cmdEnv = append(cmdEnv, k+"="+v)
+	}
+	cmdEnv = append(cmdEnv, env...)
+
+	if buildContext.Image != "" {
+		engine, err := ContainerEngine()
+		if err != nil {
+			return nil, err
+		}
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		args = commandArgs(buildContext.Image, buildContext, abs, env, name, args...)
+		name = engine
+	}
+
+	secrets := buildContext.secretValues()
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Env = cmdEnv
+	cmd.Stdout = redact(buildContext.Stdout, secrets)
+	cmd.Stderr = redact(buildContext.Stderr, secrets)
+	return cmd, nil
+}
+
+// withoutDirs returns a copy of the file list without files in directories
+// with the given names, at any depth.
+func withoutDirs(files *FileList, dirs ...string) *FileList {
+	out := &FileList{Root: files.Root}
+	for _, f := range files.Files {
+		if !inDir(filepath.ToSlash(f), dirs) {
+			out.Files = append(out.Files, f)
+		}
+	}
+	return out
+}
+
+func inDir(name string, dirs []string) bool {
+	parts := strings.Split(name, "/")
+	for _, p := range parts[:len(parts)-1] {
+		for _, d := range dirs {
+			if p == d {
+				return true
+			}
+		}
+	}
+	return false
+}
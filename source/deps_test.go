@@ -0,0 +1,190 @@
+package source
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInstallDeps_cache(t *testing.T) {
+	buildContext := &BuildContext{CacheDir: tempdir(t)}
+
+	calls := 0
+	install := func(dir string) error {
+		calls++
+		return ioutil.WriteFile(filepath.Join(dir, "dep.txt"), []byte("dep"), 0644)
+	}
+
+	for i := 0; i < 2; i++ {
+		dir, cleanup, err := installDeps(buildContext, "test-key", install)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cleanup()
+		if _, err := os.Stat(filepath.Join(dir, "dep.txt")); err != nil {
+			t.Errorf("Dependency not installed: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Install called %d times, want 1", calls)
+	}
+
+	if _, _, err := installDeps(buildContext, "other-key", install); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("Install called %d times for new key, want 2", calls)
+	}
+}
+
+func TestInstallDeps_noCache(t *testing.T) {
+	buildContext := &BuildContext{}
+
+	dir, cleanup, err := installDeps(buildContext, "test-key", func(dir string) error {
+		return ioutil.WriteFile(filepath.Join(dir, "dep.txt"), []byte("dep"), 0644)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "dep.txt")); err != nil {
+		t.Errorf("Dependency not installed: %v", err)
+	}
+	cleanup()
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("Temporary directory not removed")
+	}
+}
+
+func TestDepsKey(t *testing.T) {
+	dir := tempdir(t)
+	writeTxtar(t, dir, `
+-- package-lock.json --
+{"lockfileVersion": 1}
+`)
+	key1, err := depsKey("node", Target{}, "", dir, "package.json", "package-lock.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := depsKey("node", Target{Arch: "arm64"}, "", dir, "package.json", "package-lock.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key1 == key2 {
+		t.Errorf("Key did not change when architecture changed")
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "package-lock.json"), []byte(`{"lockfileVersion": 2}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	key3, err := depsKey("node", Target{}, "", dir, "package.json", "package-lock.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key1 == key3 {
+		t.Errorf("Key did not change when lockfile changed")
+	}
+}
+
+func TestNodeBuilder_Build(t *testing.T) {
+	if _, err := exec.LookPath("npm"); err != nil {
+		t.Skipf("Skipping test: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		files string
+		want  []string
+	}{
+		{
+			name: "NoPackage",
+			files: `
+-- index.js --
+exports.handler = async () => {}
+`,
+			want: []string{"index.js"},
+		},
+		{
+			name: "Bundle",
+			files: `
+-- index.js --
+exports.handler = async () => {}
+-- lib/util.js --
+module.exports = {}
+-- package.json --
+{"name": "test", "version": "1.0.0", "scripts": {"bundle": "cp index.js \"$FUNC_OUTPUT/bundle.js\""}}
+-- package-lock.json --
+{"name": "test", "version": "1.0.0", "lockfileVersion": 1}
+`,
+			want: []string{"bundle.js"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			src := tempdir(t)
+			writeTxtar(t, src, tc.files)
+			files, err := Collect(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out := tempdir(t)
+			buildContext := &BuildContext{
+				Dir:      out,
+				Stdout:   ioutil.Discard,
+				Stderr:   ioutil.Discard,
+				CacheDir: tempdir(t),
+			}
+			b := &NodeBuilder{}
+			if err := b.Build(context.Background(), files, Target{}, buildContext); err != nil {
+				t.Fatal(err)
+			}
+			got, err := Collect(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got.Files, tc.want); diff != "" {
+				t.Errorf("Diff (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNodeBuilder_Inputs(t *testing.T) {
+	files := &FileList{
+		Root:  "/src",
+		Files: []string{"index.js", "node_modules/lodash/index.js", "package.json"},
+	}
+	got, err := (&NodeBuilder{}).Inputs(context.Background(), files, Target{}, &BuildContext{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &FileList{
+		Root:  "/src",
+		Files: []string{"index.js", "package.json"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
+
+func TestPythonBuilder_Inputs(t *testing.T) {
+	files := &FileList{
+		Root:  "/src",
+		Files: []string{"handler.py", "__pycache__/handler.cpython-38.pyc", "lib/__pycache__/x.pyc", "lib/x.py"},
+	}
+	got, err := (&PythonBuilder{}).Inputs(context.Background(), files, Target{}, &BuildContext{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &FileList{
+		Root:  "/src",
+		Files: []string{"handler.py", "lib/x.py"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
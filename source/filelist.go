@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"time"
 )
 
 // A FileList contains a list of source files.
@@ -78,10 +79,24 @@ func (l FileList) Write(w io.Writer) error {
 	return nil
 }
 
+// zipModTime is the modification time set on all files in zip archives. It is
+// the earliest time that can be represented in a zip file.
+var zipModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
 // Zip compresses the file list to a zip archive.
+//
+// The archive is reproducible: the same files produce the same archive,
+// regardless of the order of the files, modification times or file
+// permissions. Files are added in lexicographical order with a fixed
+// modification time. Executable files are added with mode 0755, all other
+// files with 0644.
 func (l FileList) Zip(w io.Writer) error {
+	files := make([]string, len(l.Files))
+	copy(files, l.Files)
+	sort.Strings(files)
+
 	zf := zip.NewWriter(w)
-	for _, f := range l.Files {
+	for _, f := range files {
 		if err := addFileToZip(zf, l.Root, f); err != nil {
 			return err
 		}
@@ -104,12 +119,12 @@ func addFileToZip(z *zip.Writer, root, filename string) error {
 	if err != nil {
 		return err
 	}
-	header, err := zip.FileInfoHeader(info)
-	if err != nil {
-		return err
+	header := &zip.FileHeader{
+		Name:     filepath.ToSlash(filename),
+		Method:   zip.Deflate,
+		Modified: zipModTime,
 	}
-	header.Name = filename
-	header.Method = zip.Deflate
+	header.SetMode(archiveMode(info.Mode()))
 	w, err := z.CreateHeader(header)
 	if err != nil {
 		return err
@@ -119,3 +134,12 @@ func addFileToZip(z *zip.Writer, root, filename string) error {
 	}
 	return nil
 }
+
+// archiveMode returns the normalized mode for a file in an archive. Only the
+// executable bit is retained.
+func archiveMode(mode os.FileMode) os.FileMode {
+	if mode&0111 != 0 {
+		return 0755
+	}
+	return 0644
+}
@@ -5,9 +5,12 @@ import (
 	"bytes"
 	"encoding/hex"
 	"io/ioutil"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -134,3 +137,83 @@ Bar
 		}
 	}
 }
+
+func TestFileList_Zip_reproducible(t *testing.T) {
+	files := `
+-- main.go --
+package main
+-- bootstrap --
+#!/bin/sh
+-- lib/util.go --
+package lib
+`
+	// Simulate two machines with different checkouts: different file order,
+	// modification times and permissions.
+	dirA := tempdir(t)
+	writeTxtar(t, dirA, files)
+	chmod(t, filepath.Join(dirA, "bootstrap"), 0755)
+	chmod(t, filepath.Join(dirA, "main.go"), 0600)
+	touch(t, dirA, time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	dirB := tempdir(t)
+	writeTxtar(t, dirB, files)
+	chmod(t, filepath.Join(dirB, "bootstrap"), 0700)
+	chmod(t, filepath.Join(dirB, "lib/util.go"), 0664)
+	touch(t, dirB, time.Date(2021, 3, 4, 5, 6, 7, 0, time.Local))
+
+	a := &FileList{Root: dirA, Files: []string{"main.go", "bootstrap", "lib/util.go"}}
+	b := &FileList{Root: dirB, Files: []string{"lib/util.go", "main.go", "bootstrap"}}
+
+	var bufA, bufB bytes.Buffer
+	if err := a.Zip(&bufA); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Zip(&bufB); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bufA.Bytes(), bufB.Bytes()) {
+		t.Fatalf("Archives are not identical")
+	}
+
+	zf, err := zip.NewReader(bytes.NewReader(bufA.Bytes()), int64(bufA.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]os.FileMode)
+	var names []string
+	for _, f := range zf.File {
+		names = append(names, f.Name)
+		got[f.Name] = f.Mode()
+	}
+	if diff := cmp.Diff(names, []string{"bootstrap", "lib/util.go", "main.go"}); diff != "" {
+		t.Errorf("Order (-got +want)\n%s", diff)
+	}
+	want := map[string]os.FileMode{
+		"bootstrap":   0755,
+		"lib/util.go": 0644,
+		"main.go":     0644,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Modes (-got +want)\n%s", diff)
+	}
+}
+
+func chmod(t *testing.T, filename string, mode os.FileMode) {
+	t.Helper()
+	if err := os.Chmod(filename, mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func touch(t *testing.T, dir string, mtime time.Time) {
+	t.Helper()
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, mtime, mtime)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,196 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GoBuilder builds Go source code to a Lambda executable.
+//
+// The executable is cross-compiled for Linux with cgo disabled. Paths are
+// trimmed and debug information is stripped, so the output is reproducible.
+type GoBuilder struct {
+	// Package is the main package to build, relative to the source
+	// directory. If not set, the package in the source directory is built.
+	Package string
+}
+
+// Name returns "go".
+func (b *GoBuilder) Name() string { return "go" }
+
+// Tools returns the command to print the Go version.
+func (b *GoBuilder) Tools() []string { return []string{"go version"} }
+
+// Inputs returns the go.mod and go.sum files, and the files of all packages
+// within the source directory that the main package depends on. Dependencies
+// outside the source directory are covered by go.sum.
+//
+// The packages are listed with go list, in the image of the build context if
+// set, with the environment of the build context.
+func (b *GoBuilder) Inputs(ctx context.Context, files *FileList, target Target, buildContext *BuildContext) (*FileList, error) {
+	arch, err := goArch(target)
+	if err != nil {
+		return nil, err
+	}
+
+	goenv := []string{"GOOS=linux", "GOARCH=" + arch, "CGO_ENABLED=0"}
+	cmd, err := command(ctx, buildContext, files.Root, goenv, "go", "list", "-deps", "-json", b.pkg())
+	if err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stdout = nil
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	// Go list reports directories with symlinks resolved on the host. In a
+	// container, the directory is mounted to the unresolved path.
+	root, err := filepath.Abs(files.Root)
+	if err != nil {
+		return nil, err
+	}
+	roots := []string{root}
+	if resolved, err := filepath.EvalSymlinks(root); err == nil && resolved != root {
+		roots = append(roots, resolved)
+	}
+	var inputs []string
+	for _, name := range []string{"go.mod", "go.sum"} {
+		if _, err := os.Stat(filepath.Join(root, name)); err == nil {
+			inputs = append(inputs, name)
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var pkg goPackage
+		if err := dec.Decode(&pkg); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("decode go list output: %w", err)
+		}
+		if pkg.Standard {
+			continue
+		}
+		rel, ok := relPackage(roots, pkg.Dir)
+		if !ok {
+			// Outside source directory.
+			continue
+		}
+		for _, f := range pkg.files() {
+			inputs = append(inputs, filepath.Join(rel, f))
+		}
+	}
+	sort.Strings(inputs)
+
+	return &FileList{
+		Root:  files.Root,
+		Files: inputs,
+	}, nil
+}
+
+// relPackage returns the path to a package directory from the first root it
+// is within.
+func relPackage(roots []string, dir string) (string, bool) {
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		return rel, true
+	}
+	return "", false
+}
+
+// Build builds the main package. The executable is named bootstrap for
+// custom runtimes and after the handler for the go1.x runtime.
+func (b *GoBuilder) Build(ctx context.Context, files *FileList, target Target, buildContext *BuildContext) error {
+	arch, err := goArch(target)
+	if err != nil {
+		return err
+	}
+	name := goExecutable(target)
+
+	goenv := []string{"GOOS=linux", "GOARCH=" + arch, "CGO_ENABLED=0"}
+	args := []string{
+		"build",
+		"-trimpath",
+		"-ldflags=-s -w -buildid=",
+		"-o", filepath.Join(buildContext.Dir, name),
+		b.pkg(),
+	}
+
+	// The target environment is set last, so it cannot be overridden.
+	cmd, err := command(ctx, buildContext, files.Root, goenv, "go", args...)
+	if err != nil {
+		return err
+	}
+	return cmd.Run()
+}
+
+func (b *GoBuilder) pkg() string {
+	if b.Package == "" {
+		return "."
+	}
+	pkg := filepath.ToSlash(b.Package)
+	if !strings.HasPrefix(pkg, ".") {
+		pkg = "./" + pkg
+	}
+	return pkg
+}
+
+// goArch returns the GOARCH for the target architecture.
+func goArch(target Target) (string, error) {
+	switch target.Arch {
+	case "", "x86_64":
+		return "amd64", nil
+	case "arm64":
+		if target.Runtime == "go1.x" {
+			return "", fmt.Errorf("runtime go1.x does not support arm64, use provided.al2")
+		}
+		return "arm64", nil
+	default:
+		return "", fmt.Errorf("unsupported architecture %q", target.Arch)
+	}
+}
+
+// goExecutable returns the name of the executable for the target runtime.
+func goExecutable(target Target) string {
+	if target.Runtime == "go1.x" && target.Handler != "" {
+		return target.Handler
+	}
+	return "bootstrap"
+}
+
+// goPackage is a package in the output of go list -json.
+type goPackage struct {
+	Dir        string
+	Standard   bool
+	GoFiles    []string
+	CgoFiles   []string
+	CFiles     []string
+	CXXFiles   []string
+	HFiles     []string
+	SFiles     []string
+	SysoFiles  []string
+	EmbedFiles []string
+}
+
+// files returns the files that are compiled into the package. Ignored files
+// are not included.
+func (p goPackage) files() []string {
+	var out []string
+	for _, ff := range [][]string{p.GoFiles, p.CgoFiles, p.CFiles, p.CXXFiles, p.HFiles, p.SFiles, p.SysoFiles, p.EmbedFiles} {
+		out = append(out, ff...)
+	}
+	return out
+}
@@ -0,0 +1,269 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const goModule = `
+-- go.mod --
+module example.com/handler
+
+go 1.13
+-- main.go --
+package main
+
+import "example.com/handler/lib"
+
+func main() { lib.Run() }
+-- main_test.go --
+package main
+-- lib/lib.go --
+package lib
+
+func Run() {}
+-- unused/unused.go --
+package unused
+-- README.md --
+# Handler
+`
+
+func requireGo(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skipf("Skipping test: %v", err)
+	}
+}
+
+func TestGoBuilder_Inputs(t *testing.T) {
+	requireGo(t)
+
+	dir := tempdir(t)
+	writeTxtar(t, dir, goModule)
+	files, err := Collect(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &GoBuilder{}
+	got, err := b.Inputs(context.Background(), files, Target{Runtime: "provided.al2"}, &BuildContext{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &FileList{
+		Root:  dir,
+		Files: []string{"go.mod", "lib/lib.go", "main.go"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
+
+func TestGoBuilder_Inputs_env(t *testing.T) {
+	requireGo(t)
+
+	dir := tempdir(t)
+	writeTxtar(t, dir, goModule+`
+-- extra.go --
+//go:build extra
+// +build extra
+
+package main
+`)
+	files, err := Collect(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &GoBuilder{}
+	buildContext := &BuildContext{Env: map[string]string{"GOFLAGS": "-tags=extra"}}
+	got, err := b.Inputs(context.Background(), files, Target{Runtime: "provided.al2"}, buildContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"extra.go", "go.mod", "lib/lib.go", "main.go"}
+	if diff := cmp.Diff(got.Files, want); diff != "" {
+		t.Errorf("Files (-got +want)\n%s", diff)
+	}
+}
+
+func TestGoBuilder_Inputs_image(t *testing.T) {
+	dir := tempdir(t)
+	writeTxtar(t, dir, goModule)
+	files, err := Collect(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fake container engine that records its arguments and prints the go
+	// list output.
+	bin := tempdir(t)
+	argsFile := filepath.Join(bin, "args")
+	script := "#!/bin/sh\n" +
+		"echo \"$@\" > " + argsFile + "\n" +
+		"echo '{\"Dir\": \"" + dir + "\", \"GoFiles\": [\"main.go\"]}'\n"
+	if err := ioutil.WriteFile(filepath.Join(bin, "docker"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := os.Getenv("PATH")
+	_ = os.Setenv("PATH", bin+string(os.PathListSeparator)+path)
+	t.Cleanup(func() { _ = os.Setenv("PATH", path) })
+
+	b := &GoBuilder{}
+	buildContext := &BuildContext{
+		Env:   map[string]string{"GOFLAGS": "-mod=vendor"},
+		Image: "golang:1.15",
+	}
+	got, err := b.Inputs(context.Background(), files, Target{Runtime: "provided.al2"}, buildContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got.Files, []string{"go.mod", "main.go"}); diff != "" {
+		t.Errorf("Files (-got +want)\n%s", diff)
+	}
+
+	args, err := ioutil.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"--env GOFLAGS", "golang:1.15 go list -deps -json ."} {
+		if !strings.Contains(string(args), want) {
+			t.Errorf("Container args %q do not contain %q", args, want)
+		}
+	}
+}
+
+func TestGoBuilder_Build(t *testing.T) {
+	requireGo(t)
+
+	tests := []struct {
+		name    string
+		target  Target
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Provided",
+			target: Target{Runtime: "provided.al2", Handler: "ignored", Arch: "arm64"},
+			want:   "bootstrap",
+		},
+		{
+			name:   "Go1x",
+			target: Target{Runtime: "go1.x", Handler: "handler"},
+			want:   "handler",
+		},
+		{
+			name:    "Go1xArm",
+			target:  Target{Runtime: "go1.x", Handler: "handler", Arch: "arm64"},
+			wantErr: true,
+		},
+	}
+
+	src := tempdir(t)
+	writeTxtar(t, src, goModule)
+	files, err := Collect(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := tempdir(t)
+			var stderr bytes.Buffer
+			buildContext := &BuildContext{
+				Dir:    out,
+				Stdout: ioutil.Discard,
+				Stderr: &stderr,
+			}
+			b := &GoBuilder{}
+			err := b.Build(context.Background(), files, tc.target, buildContext)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Error = %v, want err = %t\n%s", err, tc.wantErr, stderr.String())
+			}
+			if tc.wantErr {
+				return
+			}
+			got, err := Collect(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got.Files, []string{tc.want}); diff != "" {
+				t.Errorf("Files (-got +want)\n%s", diff)
+			}
+			info, err := os.Stat(filepath.Join(out, tc.want))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode()&0111 == 0 {
+				t.Errorf("Output is not executable: %v", info.Mode())
+			}
+		})
+	}
+}
+
+func TestCode_BuildChecksum_goBuilder(t *testing.T) {
+	requireGo(t)
+
+	dir := tempdir(t)
+	writeTxtar(t, dir, goModule)
+	files, err := Collect(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checksum := func(arch string) string {
+		t.Helper()
+		code := &Code{
+			Files:   files,
+			Builder: &GoBuilder{},
+			Target:  Target{Runtime: "provided.al2", Arch: arch},
+		}
+		sum, err := code.BuildChecksum(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sum
+	}
+
+	before := checksum("x86_64")
+	if err := ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("Changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "unused", "unused.go"), []byte("package unused\n\nvar x = 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := checksum("x86_64"); got != before {
+		t.Errorf("Build checksum changed when files that are not build inputs changed")
+	}
+	if got := checksum("arm64"); got == before {
+		t.Errorf("Build checksum did not change when architecture changed")
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "lib", "lib.go"), []byte("package lib\n\nfunc Run() { println() }\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := checksum("x86_64"); got == before {
+		t.Errorf("Build checksum did not change when dependency changed")
+	}
+}
+
+func TestNewBuilder(t *testing.T) {
+	b, err := NewBuilder("go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Name() != "go" {
+		t.Errorf("Name() = %q, want %q", b.Name(), "go")
+	}
+	if _, err := NewBuilder("cobol"); err == nil {
+		t.Errorf("Want error for unsupported builder")
+	}
+}
@@ -0,0 +1,303 @@
+package source
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Include includes only files that match any of the given glob patterns.
+// Directories are not filtered, only the files within them.
+//
+// A pattern without a slash matches a file name at any depth. A pattern with
+// a slash is relative to the root. A ** path segment matches zero or more
+// directories.
+func Include(patterns ...string) (Filter, error) {
+	pp, err := parsePatterns(patterns)
+	if err != nil {
+		return nil, err
+	}
+	return func(name string) bool {
+		name, dir := splitDir(name)
+		if dir {
+			return true
+		}
+		for _, p := range pp {
+			if p.match(name, false) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// Exclude excludes files and directories that match the given patterns.
+//
+// Patterns use gitignore syntax: a pattern ending with a slash only matches
+// directories, a pattern starting with ! re-includes files excluded by a
+// previous pattern and the last matching pattern decides whether a file is
+// excluded. Files within an excluded directory cannot be re-included. Blank
+// patterns and patterns starting with # are ignored.
+func Exclude(patterns ...string) (Filter, error) {
+	pp, err := parsePatterns(patterns)
+	if err != nil {
+		return nil, err
+	}
+	return func(name string) bool {
+		name, dir := splitDir(name)
+		keep := true
+		for _, p := range pp {
+			if p.match(name, dir) {
+				keep = p.negate
+			}
+		}
+		return keep
+	}, nil
+}
+
+// ReadIgnoreFile reads patterns from an ignore file, such as .funcignore or
+// .gitignore. The patterns can be passed to Exclude.
+//
+// If the file does not exist, no patterns and no error are returned.
+func ReadIgnoreFile(filename string) ([]string, error) {
+	f, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var patterns []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		patterns = append(patterns, s.Text())
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if _, err := parsePatterns(patterns); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(filename), err)
+	}
+	return patterns, nil
+}
+
+// Ignore returns a filter that excludes files matched by the ignore files with
+// the given names, such as .gitignore.
+//
+// Ignore files are read from dir, from its parent directories up to root and
+// from its sub directories. Patterns are relative to the directory of the
+// ignore file and only apply to files below it. As in git, patterns in
+// deeper directories take precedence, followed by patterns in later names.
+// Hidden and excluded sub directories are not searched for ignore files.
+//
+// If root is empty or not a parent of dir, only dir and its sub directories
+// are read.
+func Ignore(root, dir string, names ...string) (Filter, error) {
+	var sets []ignoreSet
+
+	read := func(d, prefix, sub string) error {
+		for _, name := range names {
+			filename := filepath.Join(d, name)
+			patterns, err := ReadIgnoreFile(filename)
+			if err != nil {
+				return err
+			}
+			pp, _ := parsePatterns(patterns)
+			if len(pp) > 0 {
+				sets = append(sets, ignoreSet{prefix: prefix, sub: sub, patterns: pp})
+			}
+		}
+		return nil
+	}
+
+	// Parent directories, from the root down.
+	if rel, ok := relDir(root, dir); ok && rel != "." {
+		rel = filepath.ToSlash(rel)
+		parts := strings.Split(rel, "/")
+		for i := range parts {
+			d := filepath.Join(root, filepath.FromSlash(strings.Join(parts[:i], "/")))
+			if err := read(d, strings.Join(parts[i:], "/"), ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	filter := func(name string) bool {
+		name, isDir := splitDir(name)
+		keep := true
+		for _, set := range sets {
+			if matched, negate := set.match(name, isDir); matched {
+				keep = negate
+			}
+		}
+		return keep
+	}
+
+	// The directory and its sub directories. Walk visits directories before
+	// their contents, so deeper ignore files are added after the ones above.
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if path == dir && os.IsNotExist(err) {
+			// Nothing to filter.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		if rel == "." {
+			return read(path, "", "")
+		}
+		if info.Name()[0] == '.' || !filter(rel+string(filepath.Separator)) {
+			return filepath.SkipDir
+		}
+		return read(path, "", filepath.ToSlash(rel))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// relDir returns the path to dir from root, if root is a parent of dir or
+// the same directory.
+func relDir(root, dir string) (string, bool) {
+	if root == "" {
+		return "", false
+	}
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return "", false
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// ignoreSet is a set of patterns read from an ignore file.
+type ignoreSet struct {
+	// prefix is the path from the ignore file to the filtered dir, for ignore
+	// files in parent directories.
+	prefix string
+
+	// sub is the path to the ignore file from the filtered dir, for ignore
+	// files in sub directories.
+	sub string
+
+	patterns []pattern
+}
+
+// match reports whether any pattern matches the name and whether the last
+// matching pattern is negated.
+func (s ignoreSet) match(name string, dir bool) (matched, negate bool) {
+	if s.sub != "" {
+		if !strings.HasPrefix(name, s.sub+"/") {
+			return false, false
+		}
+		name = strings.TrimPrefix(name, s.sub+"/")
+	}
+	if s.prefix != "" {
+		name = s.prefix + "/" + name
+	}
+	for _, p := range s.patterns {
+		if p.match(name, dir) {
+			matched, negate = true, p.negate
+		}
+	}
+	return matched, negate
+}
+
+type pattern struct {
+	segments []string
+	negate   bool
+	dirOnly  bool
+}
+
+func parsePatterns(patterns []string) ([]pattern, error) {
+	var out []pattern
+	for _, str := range patterns {
+		str = strings.TrimRight(str, " \t\r")
+		if str == "" || str[0] == '#' {
+			continue
+		}
+		var p pattern
+		if str[0] == '!' {
+			p.negate = true
+			str = str[1:]
+		} else if strings.HasPrefix(str, `\#`) || strings.HasPrefix(str, `\!`) {
+			str = str[1:]
+		}
+		if strings.HasSuffix(str, "/") {
+			p.dirOnly = true
+			str = strings.TrimRight(str, "/")
+		}
+		if !strings.Contains(str, "/") {
+			// Match at any depth.
+			str = "**/" + str
+		}
+		str = strings.TrimPrefix(str, "/")
+		if str == "" {
+			continue
+		}
+		p.segments = strings.Split(str, "/")
+		for _, seg := range p.segments {
+			if _, err := path.Match(seg, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", str, err)
+			}
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (p pattern) match(name string, dir bool) bool {
+	if p.dirOnly && !dir {
+		return false
+	}
+	return matchSegments(p.segments, strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// splitDir converts the path to use forward slashes and reports whether it
+// is a directory, as indicated by a trailing separator.
+func splitDir(name string) (string, bool) {
+	name = filepath.ToSlash(name)
+	if strings.HasSuffix(name, "/") {
+		return strings.TrimSuffix(name, "/"), true
+	}
+	return name, false
+}
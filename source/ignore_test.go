@@ -0,0 +1,309 @@
+package source
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInclude(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		pass     map[string]bool
+	}{
+		{
+			name:     "Basename",
+			patterns: []string{"*.js"},
+			pass: map[string]bool{
+				"index.js":     true,
+				"lib/util.js":  true,
+				"package.json": false,
+				"lib/":         true,
+			},
+		},
+		{
+			name:     "Anchored",
+			patterns: []string{"lib/*.js"},
+			pass: map[string]bool{
+				"index.js":        false,
+				"lib/util.js":     true,
+				"lib/sub/deep.js": false,
+			},
+		},
+		{
+			name:     "DoubleStar",
+			patterns: []string{"lib/**/*.js", "package.json"},
+			pass: map[string]bool{
+				"lib/util.js":     true,
+				"lib/sub/deep.js": true,
+				"package.json":    true,
+				"test/foo.js":     false,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := Include(tc.patterns...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for input, want := range tc.pass {
+				got := filter(input)
+				if got != want {
+					t.Errorf("Filter %q; got = %t, want = %t", input, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExclude(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		pass     map[string]bool
+	}{
+		{
+			name:     "Basename",
+			patterns: []string{"*_test.go"},
+			pass: map[string]bool{
+				"main.go":          true,
+				"main_test.go":     false,
+				"pkg/util_test.go": false,
+				"pkg/":             true,
+			},
+		},
+		{
+			name:     "DirOnly",
+			patterns: []string{"fixtures/"},
+			pass: map[string]bool{
+				"fixtures/":     false,
+				"pkg/fixtures/": false,
+				"fixtures":      true,
+			},
+		},
+		{
+			name:     "Anchored",
+			patterns: []string{"/build", "node_modules/.cache/"},
+			pass: map[string]bool{
+				"build":                   false,
+				"src/build":               true,
+				"node_modules/.cache/":    false,
+				"node_modules/lodash/":    true,
+				"a/node_modules/.cache/":  true,
+				"node_modules/lodash.js":  true,
+				"node_modules/.cache.txt": true,
+			},
+		},
+		{
+			name:     "Negate",
+			patterns: []string{"*.md", "!README.md"},
+			pass: map[string]bool{
+				"CHANGELOG.md":   false,
+				"README.md":      true,
+				"docs/README.md": true,
+			},
+		},
+		{
+			name:     "Comments",
+			patterns: []string{"# comment", "", `\#file`},
+			pass: map[string]bool{
+				"# comment": true,
+				"#file":     false,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := Exclude(tc.patterns...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for input, want := range tc.pass {
+				got := filter(input)
+				if got != want {
+					t.Errorf("Filter %q; got = %t, want = %t", input, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExclude_invalid(t *testing.T) {
+	if _, err := Exclude("[a-"); err == nil {
+		t.Errorf("Want error")
+	}
+	if _, err := Include("foo/[", "bar"); err == nil {
+		t.Errorf("Want error")
+	}
+}
+
+func TestReadIgnoreFile(t *testing.T) {
+	dir := tempdir(t)
+	filename := filepath.Join(dir, ".funcignore")
+
+	got, err := ReadIgnoreFile(filename)
+	if err != nil {
+		t.Fatalf("Missing file: %v", err)
+	}
+	if got != nil {
+		t.Errorf("Missing file: got %v, want nil", got)
+	}
+
+	if err := ioutil.WriteFile(filename, []byte("# Tests\n*_test.go\n\n!main_test.go\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ReadIgnoreFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"# Tests", "*_test.go", "", "!main_test.go"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
+
+func TestIgnore(t *testing.T) {
+	tests := []struct {
+		name  string
+		files string
+		root  string
+		dir   string
+		pass  map[string]bool
+	}{
+		{
+			name: "Dir",
+			files: `
+-- src/.gitignore --
+*.log
+`,
+			dir: "src",
+			pass: map[string]bool{
+				"index.js":      true,
+				"debug.log":     false,
+				"sub/debug.log": false,
+			},
+		},
+		{
+			name: "Parent",
+			files: `
+-- .gitignore --
+*.log
+/build/
+node_modules/
+-- a/.gitignore --
+/src/coverage/
+`,
+			root: ".",
+			dir:  "a/src",
+			pass: map[string]bool{
+				"index.js":      true,
+				"debug.log":     false,
+				"build/":        true, // Anchored to the root
+				"node_modules/": false,
+				"coverage/":     false,
+				"sub/coverage/": true,
+			},
+		},
+		{
+			name: "NoRoot",
+			files: `
+-- .gitignore --
+*.log
+`,
+			dir: "src",
+			pass: map[string]bool{
+				"debug.log": true,
+			},
+		},
+		{
+			name: "Nested",
+			files: `
+-- src/.gitignore --
+*.tmp
+-- src/sub/.gitignore --
+/out/
+*.log
+!keep.tmp
+-- src/node_modules/.gitignore --
+*.js
+`,
+			dir: "src",
+			pass: map[string]bool{
+				"debug.log":         true,
+				"out/":              true,
+				"a.tmp":             false,
+				"keep.tmp":          false,
+				"sub/debug.log":     false,
+				"sub/x/debug.log":   false,
+				"sub/out/":          false,
+				"sub/x/out/":        true,
+				"sub/a.tmp":         false,
+				"sub/keep.tmp":      true,
+				"node_modules/a.js": false,
+			},
+		},
+		{
+			name: "ExcludedDir",
+			files: `
+-- src/.gitignore --
+vendor/
+-- src/vendor/.gitignore --
+[
+`,
+			dir: "src",
+			pass: map[string]bool{
+				"vendor/": false,
+			},
+		},
+		{
+			name: "Precedence",
+			files: `
+-- src/.gitignore --
+*.log
+-- src/.funcignore --
+!keep.log
+`,
+			dir: "src",
+			pass: map[string]bool{
+				"debug.log": false,
+				"keep.log":  true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := tempdir(t)
+			writeTxtar(t, dir, tc.files)
+			root := ""
+			if tc.root != "" {
+				root = filepath.Join(dir, tc.root)
+			}
+			filter, err := Ignore(root, filepath.Join(dir, tc.dir), ".gitignore", ".funcignore")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for input, want := range tc.pass {
+				got := filter(filepath.FromSlash(input))
+				if got != want {
+					t.Errorf("Filter %q; got = %t, want = %t", input, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIgnore_invalid(t *testing.T) {
+	dir := tempdir(t)
+	writeTxtar(t, dir, `
+-- sub/.funcignore --
+[
+`)
+	if _, err := Ignore("", dir, ".funcignore"); err == nil {
+		t.Errorf("Want error")
+	}
+}
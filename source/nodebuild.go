@@ -0,0 +1,142 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// NodeBuilder builds Node.js source code with its dependencies.
+//
+// Production dependencies are installed with npm ci, from package-lock.json.
+// The installed dependencies are cached by the lockfile.
+//
+// If package.json has a bundle script, all dependencies are installed and
+// npm run bundle is run instead. The bundle script must write its output to
+// the directory in $FUNC_OUTPUT.
+type NodeBuilder struct{}
+
+// Name returns "node".
+func (b *NodeBuilder) Name() string { return "node" }
+
+// Tools returns the commands to print the Node.js and npm versions.
+func (b *NodeBuilder) Tools() []string { return []string{"node --version", "npm --version"} }
+
+// Inputs returns all source files, except installed dependencies.
+func (b *NodeBuilder) Inputs(ctx context.Context, files *FileList, target Target, buildContext *BuildContext) (*FileList, error) {
+	return withoutDirs(files, "node_modules"), nil
+}
+
+// Build copies the source files and installs the dependencies to the output
+// directory, or runs the bundle script if there is one.
+func (b *NodeBuilder) Build(ctx context.Context, files *FileList, target Target, buildContext *BuildContext) error {
+	files = withoutDirs(files, "node_modules")
+
+	bundle, err := hasNpmScript(files.Root, "bundle")
+	if err != nil {
+		return err
+	}
+
+	if !bundle {
+		if err := files.Copy(buildContext.Dir); err != nil {
+			return err
+		}
+		return b.installDeps(ctx, files.Root, buildContext.Dir, target, buildContext, true)
+	}
+
+	// Bundle in a temporary directory, writing output to the build
+	// directory.
+	workDir, err := ioutil.TempDir("", "func-bundle")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(workDir) }()
+	if err := files.Copy(workDir); err != nil {
+		return err
+	}
+	if err := b.installDeps(ctx, files.Root, workDir, target, buildContext, false); err != nil {
+		return err
+	}
+	cmd, err := command(ctx, buildContext, workDir, []string{"FUNC_OUTPUT=" + buildContext.Dir}, "npm", "run", "bundle")
+	if err != nil {
+		return err
+	}
+	return cmd.Run()
+}
+
+// installDeps installs dependencies to node_modules in dir. If production is
+// set, dev dependencies are not installed.
+func (b *NodeBuilder) installDeps(ctx context.Context, srcDir, dir string, target Target, buildContext *BuildContext, production bool) error {
+	if _, err := os.Stat(filepath.Join(srcDir, "package.json")); os.IsNotExist(err) {
+		// No dependencies.
+		return nil
+	}
+
+	// npm ci requires a lockfile.
+	args := []string{"ci"}
+	if _, err := os.Stat(filepath.Join(srcDir, "package-lock.json")); os.IsNotExist(err) {
+		args = []string{"install", "--no-package-lock"}
+	}
+	name := b.Name()
+	if production {
+		args = append(args, "--production")
+	} else {
+		name += "-dev"
+	}
+	key, err := depsKey(name, target, buildContext.Image, srcDir, "package.json", "package-lock.json")
+	if err != nil {
+		return err
+	}
+
+	deps, cleanup, err := installDeps(buildContext, key, func(depsDir string) error {
+		manifests := []string{"package.json", "package-lock.json"}
+		for _, f := range manifests {
+			if _, err := os.Stat(filepath.Join(srcDir, f)); os.IsNotExist(err) {
+				continue
+			}
+			if err := copyFile(srcDir, f, depsDir); err != nil {
+				return err
+			}
+		}
+		cmd, err := command(ctx, buildContext, depsDir, nil, "npm", args...)
+		if err != nil {
+			return err
+		}
+		if err := cmd.Run(); err != nil {
+			return err
+		}
+		// Only keep the installed dependencies.
+		for _, f := range manifests {
+			if err := os.Remove(filepath.Join(depsDir, f)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return copyTree(deps, dir)
+}
+
+// hasNpmScript returns true if package.json in dir defines the script.
+func hasNpmScript(dir, script string) (bool, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, "package.json"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return false, err
+	}
+	_, ok := pkg.Scripts[script]
+	return ok, nil
+}
@@ -0,0 +1,65 @@
+package source
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// PythonBuilder builds Python source code with its dependencies.
+//
+// Dependencies are installed with pip from requirements.txt, next to the
+// source files. The installed dependencies are cached by requirements.txt.
+type PythonBuilder struct{}
+
+// Name returns "python".
+func (b *PythonBuilder) Name() string { return "python" }
+
+// Tools returns the command to print the pip and Python versions.
+func (b *PythonBuilder) Tools() []string { return []string{pip() + " --version"} }
+
+// Inputs returns all source files, except compiled bytecode.
+func (b *PythonBuilder) Inputs(ctx context.Context, files *FileList, target Target, buildContext *BuildContext) (*FileList, error) {
+	return withoutDirs(files, "__pycache__"), nil
+}
+
+// Build copies the source files and installs the dependencies to the output
+// directory.
+func (b *PythonBuilder) Build(ctx context.Context, files *FileList, target Target, buildContext *BuildContext) error {
+	files = withoutDirs(files, "__pycache__")
+	if err := files.Copy(buildContext.Dir); err != nil {
+		return err
+	}
+
+	requirements := filepath.Join(files.Root, "requirements.txt")
+	if _, err := os.Stat(requirements); os.IsNotExist(err) {
+		// No dependencies.
+		return nil
+	}
+
+	key, err := depsKey(b.Name(), target, buildContext.Image, files.Root, "requirements.txt")
+	if err != nil {
+		return err
+	}
+	deps, cleanup, err := installDeps(buildContext, key, func(depsDir string) error {
+		cmd, err := command(ctx, buildContext, files.Root, nil, pip(), "install", "-r", "requirements.txt", "-t", depsDir)
+		if err != nil {
+			return err
+		}
+		return cmd.Run()
+	})
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return copyTree(deps, buildContext.Dir)
+}
+
+// pip returns the pip command to use, preferring pip3.
+func pip() string {
+	if _, err := exec.LookPath("pip3"); err == nil {
+		return "pip3"
+	}
+	return "pip"
+}
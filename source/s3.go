@@ -17,10 +17,34 @@ type S3 struct {
 	bucket string
 }
 
+// An S3Opt allows modifying how the S3 client connects to S3.
+type S3Opt func(cli *s3.Client)
+
+// WithEndpoint sets a custom endpoint URL, for connecting to an S3 compatible
+// service such as MinIO.
+func WithEndpoint(url string) S3Opt {
+	return func(cli *s3.Client) {
+		cli.Config.EndpointResolver = aws.ResolveWithEndpointURL(url)
+	}
+}
+
+// WithPathStyle uses path-style addressing, where the bucket name is part of
+// the path instead of the host name. Most S3 compatible services require
+// path-style addressing.
+func WithPathStyle() S3Opt {
+	return func(cli *s3.Client) {
+		cli.ForcePathStyle = true
+	}
+}
+
 // NewS3 creates a new S3 client.
-func NewS3(cfg aws.Config, bucket string) *S3 {
+func NewS3(cfg aws.Config, bucket string, opts ...S3Opt) *S3 {
+	cli := s3.New(cfg)
+	for _, opt := range opts {
+		opt(cli)
+	}
 	return &S3{
-		cli:    s3.New(cfg),
+		cli:    cli,
 		bucket: bucket,
 	}
 }
@@ -42,6 +66,52 @@ func (s *S3) Has(ctx context.Context, key string) (bool, error) {
 	return true, nil
 }
 
+// Location returns the location of the key in the bucket.
+func (s *S3) Location(key string) Location {
+	return Location{Bucket: s.bucket, Key: key}
+}
+
+// Region returns the region the bucket is in.
+func (s *S3) Region(ctx context.Context) (string, error) {
+	return s3manager.GetBucketRegionWithClient(ctx, s.cli, s.bucket)
+}
+
+// Get returns the contents of the item with the given key. The caller must
+// close the returned reader.
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.cli.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}).Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// List returns the keys of all items that start with the given prefix.
+func (s *S3) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	var token *string
+	for {
+		resp, err := s.cli.ListObjectsV2Request(&s3.ListObjectsV2Input{
+			Bucket:            aws.String(s.bucket),
+			Prefix:            aws.String(prefix),
+			ContinuationToken: token,
+		}).Send(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range resp.Contents {
+			keys = append(keys, *obj.Key)
+		}
+		if resp.NextContinuationToken == nil {
+			return keys, nil
+		}
+		token = resp.NextContinuationToken
+	}
+}
+
 // Upload uploads a new item to S3.
 func (s *S3) Upload(ctx context.Context, key string, body io.Reader) error {
 	mgr := s3manager.NewUploaderWithClient(s.cli)
@@ -7,15 +7,43 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/aws/defaults"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/s3iface"
+	"github.com/google/go-cmp/cmp"
 )
 
+func TestNewS3_endpoint(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+	}))
+	defer srv.Close()
+
+	cfg := defaults.Config()
+	cfg.Region = "us-east-1"
+	cfg.Credentials = aws.NewStaticCredentialsProvider("key", "secret", "")
+	s := NewS3(cfg, "buc", WithEndpoint(srv.URL), WithPathStyle())
+
+	got, err := s.Has(context.Background(), "file.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got {
+		t.Errorf("Has() = false, want true")
+	}
+	if want := "/buc/file.zip"; gotPath != want {
+		t.Errorf("Path = %q, want %q", gotPath, want)
+	}
+}
+
 func TestS3_Has(t *testing.T) {
 	bucket, key := "buc", "file.zip"
 	hook := func(input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
@@ -111,19 +139,116 @@ func TestS3_Upload(t *testing.T) {
 	}
 }
 
+func TestS3_Region(t *testing.T) {
+	hook := func(input *s3.HeadBucketInput, header http.Header) (*s3.HeadBucketOutput, error) {
+		if *input.Bucket != "bucket" {
+			return nil, awserr.New("NotFound", "Not found", nil)
+		}
+		header.Set("X-Amz-Bucket-Region", "eu-west-1")
+		return &s3.HeadBucketOutput{}, nil
+	}
+	s := &S3{
+		cli: &mockS3{
+			HeadBucket: hook,
+		},
+		bucket: "bucket",
+	}
+
+	got, err := s.Region(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "eu-west-1" {
+		t.Errorf("Got = %q, want = %q", got, "eu-west-1")
+	}
+}
+
+func TestS3_Get(t *testing.T) {
+	s := &S3{
+		cli: &mockS3{
+			GetObject: func(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				if *input.Bucket != "bucket" || *input.Key != "file.json" {
+					return nil, awserr.New("NoSuchKey", "Not found", nil)
+				}
+				return &s3.GetObjectOutput{Body: ioutil.NopCloser(strings.NewReader("hello"))}, nil
+			},
+		},
+		bucket: "bucket",
+	}
+
+	r, err := s.Get(context.Background(), "file.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	got, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "hello" {
+		t.Errorf("Got = %q, want = %q", got, "hello")
+	}
+
+	if _, err := s.Get(context.Background(), "other.json"); err == nil {
+		t.Error("Want error for missing key")
+	}
+}
+
+func TestS3_List(t *testing.T) {
+	pages := map[string]*s3.ListObjectsV2Output{
+		"": {
+			Contents:              []s3.Object{{Key: aws.String("dir/a")}, {Key: aws.String("dir/b")}},
+			NextContinuationToken: aws.String("next"),
+		},
+		"next": {
+			Contents: []s3.Object{{Key: aws.String("dir/c")}},
+		},
+	}
+	s := &S3{
+		cli: &mockS3{
+			ListObjectsV2: func(input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+				if *input.Prefix != "dir/" {
+					return nil, fmt.Errorf("prefix = %q", *input.Prefix)
+				}
+				token := ""
+				if input.ContinuationToken != nil {
+					token = *input.ContinuationToken
+				}
+				return pages[token], nil
+			},
+		},
+		bucket: "bucket",
+	}
+
+	got, err := s.List(context.Background(), "dir/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"dir/a", "dir/b", "dir/c"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
+
 // ---
 
 type (
-	HeadObjectHook func(input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error)
-	PutObjectHook  func(input *s3.PutObjectInput) (*s3.PutObjectOutput, error)
+	HeadBucketHook    func(input *s3.HeadBucketInput, header http.Header) (*s3.HeadBucketOutput, error)
+	HeadObjectHook    func(input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error)
+	GetObjectHook     func(input *s3.GetObjectInput) (*s3.GetObjectOutput, error)
+	ListObjectsV2Hook func(input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error)
+	PutObjectHook     func(input *s3.PutObjectInput) (*s3.PutObjectOutput, error)
 )
 
 type mockS3 struct {
 	s3iface.ClientAPI
 
 	// Hooks
-	HeadObject HeadObjectHook
-	PutObject  PutObjectHook
+	HeadBucket    HeadBucketHook
+	HeadObject    HeadObjectHook
+	GetObject     GetObjectHook
+	ListObjectsV2 ListObjectsV2Hook
+	PutObject     PutObjectHook
 }
 
 func (m *mockS3) req() *aws.Request {
@@ -134,6 +259,15 @@ func (m *mockS3) req() *aws.Request {
 	}
 }
 
+func (m *mockS3) HeadBucketRequest(input *s3.HeadBucketInput) s3.HeadBucketRequest {
+	req := m.req()
+	req.Handlers.Send.PushBack(func(r *aws.Request) {
+		r.HTTPResponse.Header = make(http.Header)
+		r.Data, r.Error = m.HeadBucket(input, r.HTTPResponse.Header)
+	})
+	return s3.HeadBucketRequest{Request: req}
+}
+
 func (m *mockS3) HeadObjectRequest(input *s3.HeadObjectInput) s3.HeadObjectRequest {
 	req := m.req()
 	req.Handlers.Send.PushBack(func(r *aws.Request) {
@@ -142,6 +276,22 @@ func (m *mockS3) HeadObjectRequest(input *s3.HeadObjectInput) s3.HeadObjectReque
 	return s3.HeadObjectRequest{Request: req}
 }
 
+func (m *mockS3) GetObjectRequest(input *s3.GetObjectInput) s3.GetObjectRequest {
+	req := m.req()
+	req.Handlers.Send.PushBack(func(r *aws.Request) {
+		r.Data, r.Error = m.GetObject(input)
+	})
+	return s3.GetObjectRequest{Request: req}
+}
+
+func (m *mockS3) ListObjectsV2Request(input *s3.ListObjectsV2Input) s3.ListObjectsV2Request {
+	req := m.req()
+	req.Handlers.Send.PushBack(func(r *aws.Request) {
+		r.Data, r.Error = m.ListObjectsV2(input)
+	})
+	return s3.ListObjectsV2Request{Request: req}
+}
+
 func (m *mockS3) PutObjectRequest(input *s3.PutObjectInput) s3.PutObjectRequest {
 	req := m.req()
 	req.Handlers.Send.PushBack(func(r *aws.Request) {
@@ -0,0 +1,81 @@
+package source
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// An ArtifactStore stores source code archives for deployment.
+//
+// Implemented by S3 and LocalStore.
+type ArtifactStore interface {
+	// Has returns true if an archive with the given key exists.
+	Has(ctx context.Context, key string) (bool, error)
+
+	// Upload stores an archive with the given key.
+	Upload(ctx context.Context, key string, body io.Reader) error
+
+	// Location returns the location to reference the archive by when
+	// deploying it.
+	Location(key string) Location
+}
+
+// A Location is the location of a deployed source code archive in S3.
+type Location struct {
+	Bucket string
+	Key    string
+}
+
+// LocalStore stores source code archives in a local directory, for example
+// to generate a template without network access. The directory is expected to
+// be copied to the bucket before deploying the template.
+type LocalStore struct {
+	// Dir is the directory to store archives in.
+	Dir string
+
+	// Bucket is the bucket the archives are referenced in.
+	Bucket string
+}
+
+// Has returns true if the given key exists in the directory.
+func (s *LocalStore) Has(ctx context.Context, key string) (bool, error) {
+	_, err := os.Stat(filepath.Join(s.Dir, filepath.FromSlash(key)))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Upload writes a file with the given key to the directory. The file is not
+// visible until it has been completely written.
+func (s *LocalStore) Upload(ctx context.Context, key string, body io.Reader) error {
+	path := filepath.Join(s.Dir, filepath.FromSlash(key))
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(dir, ".tmp-"+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := io.Copy(f, body); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Location returns the location of the key in the bucket.
+func (s *LocalStore) Location(key string) Location {
+	return Location{Bucket: s.Bucket, Key: key}
+}
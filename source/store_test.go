@@ -0,0 +1,48 @@
+package source
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store := &LocalStore{Dir: filepath.Join(tempdir(t), "artifacts"), Bucket: "buc"}
+
+	has, err := store.Has(ctx, "abc.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if has {
+		t.Errorf("Has() = true before upload")
+	}
+
+	if err := store.Upload(ctx, "abc.zip", strings.NewReader("archive")); err != nil {
+		t.Fatal(err)
+	}
+	has, err = store.Has(ctx, "abc.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !has {
+		t.Errorf("Has() = false after upload")
+	}
+	data, err := ioutil.ReadFile(filepath.Join(store.Dir, "abc.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "archive" {
+		t.Errorf("Content = %q, want %q", data, "archive")
+	}
+
+	got := store.Location("abc.zip")
+	want := Location{Bucket: "buc", Key: "abc.zip"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Diff (-got +want)\n%s", diff)
+	}
+}
@@ -36,7 +36,7 @@ func TestStack_io(t *testing.T) {
 	tail := testNode("TAIL")
 	s.Push(tail)
 	for i := 0; i < 3; i++ {
-		s.Insert(testNode('A'+i), 1)
+		s.Insert(testNode(rune('A'+i)), 1)
 	}
 	s.Remove(head)
 